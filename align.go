@@ -0,0 +1,115 @@
+package golinewrap
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Alignment determines where the text of each line is placed in the columns
+// between the prefix and the width.
+type Alignment int
+
+const (
+	// AlignLeft places the text of each line immediately after the prefix.
+	// This is the default.
+	AlignLeft Alignment = iota
+
+	// AlignRight pads each line with spaces after the prefix so its text ends
+	// at the width.
+	AlignRight
+
+	// AlignCenter pads each line with spaces after the prefix so its text is
+	// centered between the prefix and the width.
+	AlignCenter
+
+	// AlignJustify spreads extra spaces evenly between the words of each
+	// line so its text ends at the width. The last line of a paragraph, and
+	// lines ended by a newline, are left aligned.
+	AlignJustify
+)
+
+// SetAlignment changes where the text of each line is placed in the columns
+// between the prefix and the width. Unless the alignment is AlignLeft, each
+// line is held until it is complete, rather than written as soon as possible.
+func (ww *Writer) SetAlignment(a Alignment) error {
+	if a < AlignLeft || a > AlignJustify {
+		return fmt.Errorf("cannot set unknown alignment: %d", a)
+	}
+	ww.alignment = a
+	return nil
+}
+
+// align aligns the text of the line in the line buffer, returning the number
+// of columns it added. When wrapped is true, the line is being broken because
+// the next text does not fit on it.
+func (ww *Writer) align(wrapped bool) (int, error) {
+	if ww.alignment == AlignLeft || ww.lb.Len() <= ww.lineStart {
+		return 0, nil
+	}
+
+	text := string(ww.lb.Bytes()[ww.lineStart:])
+
+	pad := ww.lineCapacity() - ww.columns(text)
+	if pad <= 0 {
+		return 0, nil
+	}
+
+	switch ww.alignment {
+	case AlignRight:
+		text = strings.Repeat(" ", pad) + text
+	case AlignCenter:
+		pad /= 2
+		text = strings.Repeat(" ", pad) + text
+	case AlignJustify:
+		if !wrapped {
+			return 0, nil
+		}
+		// A line without gaps between words is left aligned.
+		text, pad = justify(text, pad)
+	}
+
+	ww.lb.Truncate(ww.lineStart)
+	_, err := ww.lb.WriteString(text)
+	return pad, err
+}
+
+// justify returns text with pad spaces spread evenly between its words, with
+// any extra spaces going to the gaps on the left, and the number of spaces it
+// added, which is zero when text has no gaps. Spaces within escape sequences
+// are not gaps between words.
+func justify(text string, pad int) (string, int) {
+	var gaps int
+	for i := 0; i < len(text); i++ {
+		if l := escapeLength(text[i:]); l > 0 {
+			i += l - 1
+		} else if text[i] == ' ' {
+			gaps++
+		}
+	}
+	if gaps == 0 {
+		return text, 0
+	}
+
+	var b strings.Builder
+	b.Grow(len(text) + pad)
+
+	var gap int
+	for i := 0; i < len(text); i++ {
+		if l := escapeLength(text[i:]); l > 0 {
+			b.WriteString(text[i : i+l])
+			i += l - 1
+			continue
+		}
+		b.WriteByte(text[i])
+		if text[i] == ' ' {
+			n := pad / gaps
+			if gap < pad%gaps {
+				n++
+			}
+			b.WriteString(strings.Repeat(" ", n))
+			gap++
+		}
+	}
+
+	return b.String(), pad
+}
@@ -0,0 +1,87 @@
+package golinewrap_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/karrick/golinewrap"
+)
+
+func TestAlignment(t *testing.T) {
+	emit := func(t *testing.T, a golinewrap.Alignment, width int, prefix string, p string) string {
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, width, prefix)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.SetAlignment(a); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = lw.WriteParagraph(p); err != nil {
+			t.Fatal(err)
+		}
+
+		return string(bb.Bytes())
+	}
+
+	t.Run("right", func(t *testing.T) {
+		got := emit(t, golinewrap.AlignRight, 12, ">", "one two three four")
+		if want := ">   one two\n>three four\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("center", func(t *testing.T) {
+		got := emit(t, golinewrap.AlignCenter, 12, ">", "one two three four")
+		if want := "> one two\n>three four\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("justify", func(t *testing.T) {
+		got := emit(t, golinewrap.AlignJustify, 16, "> ", "a bb cc ddd eeee f gg hhhhh")
+		if want := "> a  bb  cc ddd\n> eeee   f   gg\n> hhhhh\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("justify styled text", func(t *testing.T) {
+		got := emit(t, golinewrap.AlignJustify, 10, ">", "\x1b[1mone two three\x1b[0m")
+		if want := ">\x1b[1mone  two\x1b[0m\n>\x1b[1mthree\x1b[0m\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("right with WriteWord", func(t *testing.T) {
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, 9, ">")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.SetAlignment(golinewrap.AlignRight); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, w := range []string{"one", "two", "three"} {
+			if _, err = lw.WriteWord(w); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if got, want := string(bb.Bytes()), ">one two\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("invalid alignment", func(t *testing.T) {
+		lw, err := golinewrap.New(new(bytes.Buffer), 8, ">")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.SetAlignment(golinewrap.Alignment(42)); err == nil {
+			t.Errorf("GOT: %v; WANT: error", err)
+		}
+	})
+}
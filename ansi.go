@@ -0,0 +1,270 @@
+package golinewrap
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	esc       = '\x1b'
+	sgrReset  = "\x1b[0m"
+	linkClose = "\x1b]8;;\x1b\\"
+)
+
+// escapeLength returns the number of bytes in the ANSI escape sequence at the
+// start of s, or 0 when s does not start with an escape sequence. Control
+// Sequence Introducer (CSI) sequences, such as Select Graphic Rendition (SGR),
+// end with a final byte in the range 0x40 to 0x7E. Operating System Command
+// (OSC) sequences end with either BEL or String Terminator (ST). All other
+// escape sequences are two bytes long. An incomplete sequence consumes the
+// remainder of s.
+func escapeLength(s string) int {
+	if len(s) == 0 || s[0] != esc {
+		return 0
+	}
+	if len(s) == 1 {
+		return 1
+	}
+
+	switch s[1] {
+	case '[':
+		for i := 2; i < len(s); i++ {
+			if s[i] >= 0x40 && s[i] <= 0x7e {
+				return i + 1
+			}
+		}
+	case ']':
+		for i := 2; i < len(s); i++ {
+			if s[i] == '\a' {
+				return i + 1
+			}
+			if s[i] == esc && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2
+			}
+		}
+	default:
+		return 2
+	}
+
+	return len(s)
+}
+
+// isCompleteEscape returns true when s is a complete escape sequence.
+func isCompleteEscape(s string) bool {
+	if len(s) < 2 || s[0] != esc {
+		return false
+	}
+	switch s[1] {
+	case '[':
+		c := s[len(s)-1]
+		return len(s) > 2 && c >= 0x40 && c <= 0x7e
+	case ']':
+		return s[len(s)-1] == '\a' || (len(s) > 3 && strings.HasSuffix(s, "\x1b\\"))
+	default:
+		return true
+	}
+}
+
+// trackEscape updates the active graphic rendition and hyperlink of the
+// Writer after seq, a complete escape sequence, has been written to the line
+// buffer.
+func (ww *Writer) trackEscape(seq string) {
+	if strings.HasPrefix(seq, "\x1b]8;") {
+		ww.trackHyperlink(seq)
+		return
+	}
+
+	if len(seq) < 3 || seq[1] != '[' || seq[len(seq)-1] != 'm' {
+		return // not SGR
+	}
+
+	ww.sgr.apply(seq[2 : len(seq)-1])
+}
+
+// sgrAttributes lists the SGR parameters that turn on each attribute tracked
+// by graphicRendition, indexed by the bit of the attribute.
+var sgrAttributes = [...]string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "21", "53"}
+
+// sgrAttributesOff maps each SGR parameter that turns off attributes to the
+// bits of the attributes it turns off.
+var sgrAttributesOff = map[string]uint16{
+	"22": 1<<0 | 1<<1, // normal intensity
+	"23": 1 << 2,      // not italic
+	"24": 1<<3 | 1<<9, // not underlined
+	"25": 1<<4 | 1<<5, // not blinking
+	"27": 1 << 6,      // not inverse
+	"28": 1 << 7,      // not concealed
+	"29": 1 << 8,      // not crossed out
+	"55": 1 << 10,     // not overlined
+}
+
+// graphicRendition is the active graphic rendition of a Writer. Rather than
+// keeping every SGR sequence written, it keeps only their combined effect, so
+// a parameter is dropped once a later one cancels it, and the sequence that
+// restores the graphic rendition after each wrap does not grow.
+type graphicRendition struct {
+	attributes uint16   // bit for each attribute of sgrAttributes turned on
+	fg, bg, ul string   // parameters selecting foreground, background, and underline colors
+	other      []string // other parameters, in the order first given
+}
+
+// apply updates the graphic rendition for params, the parameters of an SGR
+// sequence.
+func (gr *graphicRendition) apply(params string) {
+	fields := strings.Split(params, ";")
+
+	for i := 0; i < len(fields); i++ {
+		param := fields[i]
+		if param == "" {
+			param = "0" // omitted parameter resets
+		}
+
+		code := param
+		if j := strings.IndexByte(param, ':'); j >= 0 {
+			code = param[:j]
+		}
+
+		n, err := strconv.Atoi(code)
+		if err != nil {
+			gr.addOther(param)
+			continue
+		}
+		if code == param {
+			code = strconv.Itoa(n) // drop leading zeros
+			param = code
+		}
+
+		switch {
+		case n == 0:
+			*gr = graphicRendition{}
+		case n == 38 || n == 48 || n == 58:
+			if code == param {
+				// Extended colors use parameters that follow: 5 and an index,
+				// or 2 and red, green, and blue components.
+				end := i
+				if i+1 < len(fields) {
+					switch fields[i+1] {
+					case "5":
+						end = i + 2
+					case "2":
+						end = i + 4
+					}
+				}
+				if end > len(fields)-1 {
+					end = len(fields) - 1
+				}
+				param = strings.Join(fields[i:end+1], ";")
+				i = end
+			}
+			switch n {
+			case 38:
+				gr.fg = param
+			case 48:
+				gr.bg = param
+			default:
+				gr.ul = param
+			}
+		case n == 39:
+			gr.fg = ""
+		case n == 49:
+			gr.bg = ""
+		case n == 59:
+			gr.ul = ""
+		case code != param:
+			gr.addOther(param)
+		case n >= 30 && n <= 37, n >= 90 && n <= 97:
+			gr.fg = param
+		case n >= 40 && n <= 47, n >= 100 && n <= 107:
+			gr.bg = param
+		default:
+			gr.setAttribute(param)
+		}
+	}
+}
+
+// setAttribute turns on or off the attribute that param, a single SGR
+// parameter, selects, or keeps param with the other parameters when it selects
+// none of them.
+func (gr *graphicRendition) setAttribute(param string) {
+	if bits, ok := sgrAttributesOff[param]; ok {
+		gr.attributes &^= bits
+		return
+	}
+	for bit, on := range sgrAttributes {
+		if param == on {
+			gr.attributes |= 1 << bit
+			return
+		}
+	}
+	gr.addOther(param)
+}
+
+// addOther keeps param, an SGR parameter the graphicRendition does not
+// otherwise track, unless it is already kept.
+func (gr *graphicRendition) addOther(param string) {
+	for _, p := range gr.other {
+		if p == param {
+			return
+		}
+	}
+	gr.other = append(gr.other, param)
+}
+
+// active returns true when any part of the graphic rendition differs from the
+// default.
+func (gr *graphicRendition) active() bool {
+	return gr.attributes != 0 || gr.fg != "" || gr.bg != "" || gr.ul != "" || len(gr.other) > 0
+}
+
+// String returns the SGR sequence that selects the graphic rendition, or the
+// empty string when it is the default.
+func (gr *graphicRendition) String() string {
+	if !gr.active() {
+		return ""
+	}
+
+	var params []string
+	for bit, on := range sgrAttributes {
+		if gr.attributes&(1<<bit) != 0 {
+			params = append(params, on)
+		}
+	}
+	for _, color := range []string{gr.fg, gr.bg, gr.ul} {
+		if color != "" {
+			params = append(params, color)
+		}
+	}
+	params = append(params, gr.other...)
+
+	return "\x1b[" + strings.Join(params, ";") + "m"
+}
+
+// trackHyperlink updates the active hyperlink of the Writer after seq, an OSC
+// 8 escape sequence, has been written to the line buffer. An OSC 8 sequence
+// with an empty URI closes the active hyperlink.
+func (ww *Writer) trackHyperlink(seq string) {
+	body := strings.TrimSuffix(strings.TrimSuffix(seq, "\a"), "\x1b\\")
+	body = body[len("\x1b]8;"):]
+
+	i := strings.IndexByte(body, ';')
+	if i < 0 || i == len(body)-1 {
+		ww.link = ""
+		return
+	}
+	ww.link = seq
+}
+
+// trackEscapes updates the active graphic rendition and hyperlink of the
+// Writer for every escape sequence in s.
+func (ww *Writer) trackEscapes(s string) {
+	for {
+		i := strings.IndexByte(s, esc)
+		if i < 0 {
+			return
+		}
+		s = s[i:]
+		n := escapeLength(s)
+		ww.trackEscape(s[:n])
+		s = s[n:]
+	}
+}
@@ -0,0 +1,121 @@
+package golinewrap_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/karrick/golinewrap"
+)
+
+func TestEscapeSequences(t *testing.T) {
+	emit := func(t *testing.T, width int, prefix string, p string) string {
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, width, prefix)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = lw.WriteParagraph(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return string(bb.Bytes())
+	}
+
+	t.Run("SGR occupies no columns", func(t *testing.T) {
+		got := emit(t, 15, ">", "\x1b[31merror\x1b[0m: failed")
+		if want := ">\x1b[31merror\x1b[0m: failed\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("OSC occupies no columns", func(t *testing.T) {
+		got := emit(t, 10, ">", "\x1b]0;title\aone two")
+		if want := ">\x1b]0;title\aone two\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("style carried across wrap", func(t *testing.T) {
+		got := emit(t, 9, ">", "\x1b[1mone two three\x1b[0m four")
+		want := ">\x1b[1mone two\x1b[0m\n>\x1b[1mthree\x1b[0m\n>four\n>\n"
+		if got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("combined styles carried across wrap", func(t *testing.T) {
+		got := emit(t, 9, ">", "\x1b[1m\x1b[31mone two three")
+		want := ">\x1b[1m\x1b[31mone two\x1b[0m\n>\x1b[1;31mthree\x1b[0m\n>\x1b[1;31m\x1b[0m\n"
+		if got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("cancelled styles not carried across wrap", func(t *testing.T) {
+		got := emit(t, 9, ">", "\x1b[1;4mone\x1b[22m \x1b[32mtwo\x1b[39;44m three")
+		want := ">\x1b[1;4mone\x1b[22m \x1b[32mtwo\x1b[39;44m\x1b[0m\n>\x1b[4;44mthree\x1b[0m\n>\x1b[4;44m\x1b[0m\n"
+		if got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("extended colors carried across wrap", func(t *testing.T) {
+		got := emit(t, 9, ">", "\x1b[38;5;208;48;2;1;2;3;1mone two three")
+		want := ">\x1b[38;5;208;48;2;1;2;3;1mone two\x1b[0m\n>\x1b[1;38;5;208;48;2;1;2;3mthree\x1b[0m\n>\x1b[1;38;5;208;48;2;1;2;3m\x1b[0m\n"
+		if got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("restored style does not grow", func(t *testing.T) {
+		got := emit(t, 20, ">", strings.Repeat("\x1b[1mbold\x1b[22m plain ", 200))
+		for _, line := range strings.Split(got, "\n") {
+			if len(line) > 40 {
+				t.Fatalf("GOT: %q; WANT: line no longer than 40 bytes", line)
+			}
+		}
+	})
+
+	t.Run("hyperlink closed and reopened across wrap", func(t *testing.T) {
+		const open = "\x1b]8;;https://example.com\x1b\\"
+		const closed = "\x1b]8;;\x1b\\"
+		got := emit(t, 10, ">", "see "+open+"the example"+closed+" site")
+		want := ">see " + open + "the" + closed + "\n>" + open + "example" + closed + "\n>site\n>\n"
+		if got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("hyperlink and style", func(t *testing.T) {
+		const open = "\x1b]8;id=1;https://example.com\a"
+		const closed = "\x1b]8;;\a"
+		got := emit(t, 10, ">", "\x1b[4m"+open+"one two three"+closed+"\x1b[0m")
+		want := ">\x1b[4m" + open + "one two" + "\x1b]8;;\x1b\\" + "\x1b[0m\n>\x1b[4m" + open + "three" + closed + "\x1b[0m\n>\n"
+		if got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("WriteRune", func(t *testing.T) {
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, 5, ">")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, r := range "\x1b[32m12345\x1b[0m6" {
+			if _, err = lw.WriteRune(r); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if got, want := string(bb.Bytes()), ">\x1b[32m123\x1b[0m\n>\x1b[32m45\x1b[0m6"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+}
@@ -3,10 +3,8 @@ package main
 import (
 	"fmt"
 	"io"
-	"io/ioutil"
 	"os"
 	"path/filepath"
-	"strings"
 
 	"github.com/karrick/golf"
 	"github.com/karrick/golinewrap"
@@ -34,7 +32,14 @@ func main() {
 
 	// NOTE: When line wrapping based on terminal width, remember to save one
 	// column for the newline character, or your output will be stuttered.
-	lw, err := golinewrap.New(os.Stdout, *optWidth-1, "")
+	// NewForTerminal does so itself.
+	var lw *golinewrap.Writer
+	var err error
+	if *optWidth == 0 {
+		lw, err = golinewrap.NewForTerminal(os.Stdout, golinewrap.WithResizeWatch(true))
+	} else {
+		lw, err = golinewrap.New(os.Stdout, *optWidth-1, "")
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "%s\n", err)
 		os.Exit(1)
@@ -47,16 +52,15 @@ func main() {
 		ior = &gorill.FilesReader{Pathnames: golf.Args()}
 	}
 
-	// WARNING: This is very inefficient implementation that slurps up entire
-	// file.
+	// Reflow the input so each paragraph is on a single line, then stream it
+	// through the Writer, which holds only a partial line in memory.
+	lw.SetStreaming(true)
 
-	buf, err := ioutil.ReadAll(ior)
+	if _, err = io.Copy(lw, golinewrap.NewReflowReader(ior)); err == nil {
+		err = lw.Close()
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "ERROR: %s\n", err)
 		os.Exit(1)
 	}
-
-	for _, paragraph := range strings.Split(string(buf), "\n\n") {
-		lw.WriteParagraph(strings.Replace(paragraph, "\n", " ", -1))
-	}
 }
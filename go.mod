@@ -1 +1,3 @@
 module github.com/karrick/golinewrap
+
+go 1.18
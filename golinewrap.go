@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"unicode"
 	"unicode/utf8"
 )
 
@@ -17,116 +18,427 @@ type Writer struct {
 	remaining     int // remaining columns in the line buffer
 	prefixColumns int // number of columns used by prefix
 	prefix        string
+	firstPrefix   string             // prefix for first line of each paragraph when hanging
+	firstColumns  int                // number of columns used by firstPrefix
+	hanging       bool               // whether first line of each paragraph uses firstPrefix
+	first         bool               // whether current line is first line of a paragraph
+	linePrefix    string             // prefix written at start of current line
+	lineColumns   int                // number of columns used by prefix of current line
+	prefixFunc    PrefixFunc         // builds prefix of each line when not nil
+	suffix        string             // written at right edge of each line
+	suffixColumns int                // number of columns used by suffix
+	preformatted  PreformattedPolicy // what to do with preformatted lines wider than a line
+	verbatim      bool               // whether lines are written without trimming or alignment
+	line          int                // number of lines completed
+	paragraph     int                // number of paragraphs completed
+	measure       Measure            // how columns are counted
+	gs            graphemeState      // grapheme cluster state for WriteRune
+	cluster       []byte             // extended grapheme cluster being written by WriteRune
+	esc           []byte             // incomplete escape sequence being written by WriteRune
+	sgr           graphicRendition   // active graphic rendition
+	link          string             // escape sequence that opened the active hyperlink
+	longWord      LongWordPolicy     // what to do with words wider than a line
+	mark          string             // continuation mark for broken long words
+	hyphenator    *Hyphenator        // finds points at which words may be hyphenated
+	breaking      Breaking           // how WriteParagraph chooses line breaks
+	penalties     Penalties          // costs of lines for BreakOptimal
+	alignment     Alignment          // how lines are aligned between prefix and width
+	lineStart     int                // offset in line buffer where text of line begins
+	building      bool               // whether a paragraph is being built
+	pb            bytes.Buffer       // pieces of paragraph being built
+	written       int64              // number of bytes written to underlying io.Writer
+	streaming     bool               // whether Write holds partial words and lines
+	in            []byte             // text given to Write not yet written when streaming
+	partial       bool               // whether words of a partial line have been written when streaming
+	newlines      Newlines           // how Write interprets newlines
+	rf            reflower           // joins lines of paragraphs given to Write when newlines are soft
+	tabWidth      int                // number of columns between tab stops
+	tabOrigin     TabOrigin          // column from which tab stops are measured
+	resize        int32              // width of resized terminal not yet applied, accessed atomically
+	stopResize    func()             // stops watching for terminal resizes
+	nextWidth     int                // width set by SetWidth for next line, when not zero
+	indents       []indentLevel      // levels of indentation pushed by Indent
+	indent        string             // written after prefix of each line
+	indentColumns int                // number of columns used by indent
 }
 
 // New returns a new Writer using the specified width and prefix string for each
-// line.
+// line. Columns are measured in terminal cells; use SetMeasure to count runes
+// instead. It is equivalent to NewWithOptions with WithWidth and WithPrefix,
+// and returns a WidthError unless width is greater than the number of columns
+// used by prefix.
 func New(w io.Writer, width int, prefix string) (*Writer, error) {
-	prefixColumns := utf8.RuneCountInString(prefix)
+	return NewWithOptions(w, WithWidth(width), WithPrefix(prefix))
+}
 
-	if width <= 0 || width <= prefixColumns {
-		return nil, fmt.Errorf("cannot create Writer unless width (%d) is greater than zero and greater than number of columns used by prefix: %d.", width, prefixColumns)
+// SetMeasure changes how the Writer counts the columns used by the prefix and
+// by the text written to it. It returns an error when the prefix would no
+// longer fit within the width using the new measure.
+func (ww *Writer) SetMeasure(m Measure) error {
+	if m != MeasureCells && m != MeasureRunes {
+		return fmt.Errorf("cannot set unknown measure: %d", m)
 	}
 
-	// NOTE: The line buffer is sized for single byte runes, and will be
-	// extended as required when runes that require more than a single byte are
-	// emitted.
+	suffixColumns := measure(m, ww.suffix)
+	indentColumns := measure(m, ww.indent)
+
+	// Following lines use the width set by SetWidth, when there is one.
+	width := ww.width()
 
-	ww := &Writer{
-		Writer:        w,
-		lb:            bytes.NewBuffer(make([]byte, 0, width+1)),
-		max:           width,
-		prefixColumns: prefixColumns,
-		remaining:     width,
+	prefixColumns := measure(m, ww.prefix)
+	if width <= prefixColumns+indentColumns+suffixColumns {
+		return &WidthError{Op: "set measure", Width: width, Used: "prefix and suffix", Columns: prefixColumns + indentColumns + suffixColumns}
 	}
 
-	if prefixColumns > 0 {
-		_, err := ww.lb.WriteString(prefix)
-		if err != nil {
-			return nil, err
-		}
-		ww.prefix = prefix
-		ww.remaining -= ww.prefixColumns
+	firstColumns := measure(m, ww.firstPrefix)
+	if width <= firstColumns+indentColumns+suffixColumns {
+		return &WidthError{Op: "set measure", Width: width, Used: "first line prefix and suffix", Columns: firstColumns + indentColumns + suffixColumns}
+	}
+
+	lineColumns := measure(m, ww.linePrefix)
+	if ww.max <= lineColumns+suffixColumns {
+		return &WidthError{Op: "set measure", Width: ww.max, Used: "prefix and suffix", Columns: lineColumns + suffixColumns}
 	}
 
-	return ww, nil
+	// Adjust the remaining columns in the current line for the change in the
+	// number of columns its prefix and suffix use.
+	ww.remaining += ww.lineColumns - lineColumns + ww.suffixColumns - suffixColumns
+	ww.lineColumns = lineColumns
+	ww.prefixColumns = prefixColumns
+	ww.firstColumns = firstColumns
+	ww.suffixColumns = suffixColumns
+	ww.indentColumns = indentColumns
+	ww.measure = m
+
+	return nil
 }
 
 // flush flushes the contents of line buffer to underlying Writer. This method
 // is called at the conclusion of every public method, not necessarily for each
-// line.
+// line. Unless the text is left aligned, a line is held in the line buffer
+// until it is complete, because its alignment depends on all of its text.
 func (ww *Writer) flush() (int, error) {
+	if ww.alignment != AlignLeft {
+		return 0, nil
+	}
+	return ww.flushLine()
+}
+
+// flushLine writes the contents of line buffer to the underlying Writer.
+func (ww *Writer) flushLine() (int, error) {
 	debug("flush: %q\n", string(ww.lb.Bytes()))
 	if ww.lb.Len() == 0 {
 		return 0, nil
 	}
 	nw, err := ww.lb.WriteTo(ww.Writer)
+	ww.written += nw
+	ww.lineStart = 0
 	return int(nw), err
 }
 
 // newline appends newline to line buffer then flushes to underlying writer
-// because this library is line based.
+// because this library is line based. It is called for the last line of a
+// paragraph, and when the caller explicitly ends a line.
 func (ww *Writer) newline() (int, error) {
+	return ww.endLine(false, false)
+}
+
+// wrap appends newline to line buffer like newline, but is called when a line
+// is broken because the next text does not fit on it.
+func (ww *Writer) wrap() (int, error) {
+	return ww.endLine(true, false)
+}
+
+// endParagraph appends newline to line buffer like newline, but the next line
+// begins a new paragraph, so its prefix is built for the first line of one.
+func (ww *Writer) endParagraph() (int, error) {
+	return ww.endLine(false, true)
+}
+
+func (ww *Writer) endLine(wrapped, paragraph bool) (int, error) {
 	debug("newline\n")
 
-	b := ww.lb.Bytes()
-	if l := len(b); l > 0 && b[l-1] == ' ' {
-		ww.lb.Truncate(l - 1) // remove final space character from line buffer.
+	var trimmed, added int
+
+	if b := ww.lb.Bytes(); ww.lineEmpty() && bytes.HasPrefix(b, []byte(ww.linePrefix)) {
+		// A line without text does not keep the trailing spaces of its
+		// prefix, but does keep any escape sequences written after it.
+		prefix := strings.TrimRight(ww.linePrefix, " ")
+		tail := string(b[len(ww.linePrefix):])
+		trimmed = len(ww.linePrefix) - len(prefix)
+
+		ww.lb.Reset()
+		if _, err := ww.lb.WriteString(prefix + tail); err != nil {
+			return 0, err
+		}
+	} else if !ww.verbatim {
+		b := ww.lb.Bytes()
+		if l := len(b); l > 0 && b[l-1] == ' ' {
+			ww.lb.Truncate(l - 1) // remove final space character from line buffer.
+			trimmed = 1
+		}
+
+		var err error
+		if added, err = ww.align(wrapped); err != nil {
+			return 0, err
+		}
+	}
+
+	if ww.link != "" {
+		// Close the hyperlink so the prefix of the next line is not part of
+		// it.
+		if _, err := ww.lb.WriteString(linkClose); err != nil {
+			return 0, err
+		}
+	}
+
+	if ww.sgr.active() {
+		// Reset the graphic rendition so neither the end of this line nor the
+		// prefix of the next line is styled.
+		if _, err := ww.lb.WriteString(sgrReset); err != nil {
+			return 0, err
+		}
+	}
+
+	if ww.suffix != "" {
+		// Pad the line with spaces so the suffix ends at the width.
+		if pad := ww.remaining - 1 + trimmed - added; pad > 0 {
+			if _, err := ww.lb.WriteString(strings.Repeat(" ", pad)); err != nil {
+				return 0, err
+			}
+		}
+		if _, err := ww.lb.WriteString(ww.suffix); err != nil {
+			return 0, err
+		}
 	}
 
 	if _, err := ww.lb.WriteRune('\n'); err != nil {
 		return 0, err
 	}
 
-	// After newline written, the entire line length is available.
-	ww.remaining = ww.max
+	// After newline written, the entire line length is available, less the
+	// columns used by the suffix, and the next rune begins a new extended
+	// grapheme cluster. A change in the width of the terminal, or one made by
+	// SetWidth, takes effect here.
+	ww.applyResize()
+	if ww.nextWidth > 0 {
+		ww.max, ww.nextWidth = ww.nextWidth, 0
+	}
+	ww.remaining = ww.max - ww.suffixColumns
+	ww.first = paragraph
+	if paragraph {
+		ww.paragraph++
+	}
+	ww.line++
+	ww.gs = graphemeState{}
+	ww.cluster = ww.cluster[:0]
 
 	// Because this library is meant to be line based, go ahead and flush the
 	// contents of the line buffer after each newline.
-	nw, err := ww.flush()
+	nw, err := ww.flushLine()
 	if err != nil {
 		return nw, err
 	}
 
-	return nw, ww.writePrefix()
+	if err = ww.writePrefix(); err != nil {
+		return nw, err
+	}
+
+	// Restore the graphic rendition and hyperlink that were active at the end
+	// of the previous line.
+	if _, err = ww.lb.WriteString(ww.sgr.String()); err != nil {
+		return nw, err
+	}
+	_, err = ww.lb.WriteString(ww.link)
+	return nw, err
 }
 
+// writePrefix writes the prefix of the current line to the line buffer. When
+// a PrefixFunc has been set, it builds the prefix, which is measured each time.
+// Otherwise the first line of each paragraph uses the first line prefix when
+// one has been set, and all other lines use the continuation prefix.
 func (ww *Writer) writePrefix() error {
 	debug("write prefix\n")
 
-	if ww.prefixColumns == 0 {
+	switch {
+	case ww.prefixFunc != nil:
+		prefix := ww.prefixFunc(LineInfo{Line: ww.line + 1, Paragraph: ww.paragraph + 1, First: ww.first})
+		columns := ww.columns(prefix)
+		if ww.max <= columns+ww.indentColumns+ww.suffixColumns {
+			return &WidthError{Op: "write prefix", Width: ww.max, Used: "prefix and suffix", Columns: columns + ww.indentColumns + ww.suffixColumns}
+		}
+		ww.linePrefix, ww.lineColumns = prefix, columns
+	case ww.first && ww.hanging:
+		ww.linePrefix, ww.lineColumns = ww.firstPrefix, ww.firstColumns
+	default:
+		ww.linePrefix, ww.lineColumns = ww.prefix, ww.prefixColumns
+	}
+
+	// Indentation follows the prefix.
+	ww.linePrefix += ww.indent
+	ww.lineColumns += ww.indentColumns
+
+	if ww.lineColumns == 0 {
 		return nil
 	}
 
-	ww.remaining -= ww.prefixColumns
-	_, err := ww.lb.WriteString(ww.prefix)
+	ww.remaining -= ww.lineColumns
+	_, err := ww.lb.WriteString(ww.linePrefix)
+	ww.lineStart = ww.lb.Len()
+
+	return err
+}
+
+// rewritePrefix replaces the prefix of the current line when nothing has been
+// written after it, so a change to the prefixes takes effect on the current
+// line rather than the next one.
+func (ww *Writer) rewritePrefix() error {
+	if !ww.lineEmpty() || ww.lineStart != len(ww.linePrefix) || !bytes.HasPrefix(ww.lb.Bytes(), []byte(ww.linePrefix)) {
+		return nil // text already written on current line
+	}
+
+	// Keep the escape sequences that restore the graphic rendition and
+	// hyperlink after the prefix.
+	tail := string(ww.lb.Bytes()[ww.lineStart:])
+
+	ww.lb.Reset()
+	ww.remaining = ww.max - ww.suffixColumns
+	ww.lineStart = 0
 
+	if err := ww.writePrefix(); err != nil {
+		return err
+	}
+	_, err := ww.lb.WriteString(tail)
 	return err
 }
 
+// SetWidth changes the number of columns to wrap text to, including the column
+// used by the newline character. It takes effect with the next line, or with
+// the current one when nothing has been written to it yet, so text already
+// written to the current line is kept. It returns a WidthError unless width is
+// greater than the number of columns used by each prefix together with the
+// suffix.
+func (ww *Writer) SetWidth(width int) error {
+	if err := ww.setWidth(width); err != nil {
+		return err
+	}
+
+	// Dedent restores the width of a level that changes it.
+	if n := len(ww.indents); n > 0 {
+		ww.indents[n-1].widthSet = true
+	}
+	return nil
+}
+
+// setWidth changes the width, as described for SetWidth.
+func (ww *Writer) setWidth(width int) error {
+	if err := ww.checkWidth(width, ww.indentColumns); err != nil {
+		return err
+	}
+
+	if !ww.lineEmpty() {
+		ww.nextWidth = width
+		return nil
+	}
+
+	if width <= ww.lineColumns+ww.suffixColumns {
+		return &WidthError{Op: "set width", Width: width, Used: "prefix and suffix", Columns: ww.lineColumns + ww.suffixColumns}
+	}
+	ww.remaining += width - ww.max
+	ww.max, ww.nextWidth = width, 0
+	return nil
+}
+
+// width returns the width that applies to the next line.
+func (ww *Writer) width() int {
+	if ww.nextWidth > 0 {
+		return ww.nextWidth
+	}
+	return ww.max
+}
+
+// SetFirstLinePrefix sets the prefix for the first line of each paragraph,
+// leaving the prefix given to New for the remaining lines, which makes it easy
+// to write hanging indents. It takes effect with the next paragraph, or with
+// the current one when nothing has been written to it yet. It returns an error
+// unless width is greater than the number of columns used by prefix.
+func (ww *Writer) SetFirstLinePrefix(prefix string) error {
+	firstColumns := measure(ww.measure, prefix)
+	if width := ww.width(); width <= firstColumns+ww.indentColumns+ww.suffixColumns {
+		return &WidthError{Op: "set first line prefix", Width: width, Used: "prefix and suffix", Columns: firstColumns + ww.indentColumns + ww.suffixColumns}
+	}
+
+	ww.firstPrefix = prefix
+	ww.firstColumns = firstColumns
+	ww.hanging = true
+
+	if !ww.first {
+		return nil
+	}
+	return ww.rewritePrefix()
+}
+
 // Printf formats its arguments using `fmt.Sprintf`, then writes the resultant
-// string.
+// string as Write does, returning the number of bytes of that string consumed.
 func (ww *Writer) Printf(format string, a ...interface{}) (int, error) {
 	return ww.Write([]byte(fmt.Sprintf(format, a...)))
 }
 
 // Write writes buf to the underlying io.Writer. It converts the input to a
-// string, splits on newline, and emits each line as a paragraph.
+// string, splits on newline, and emits each line as a paragraph. Call
+// SetNewlines to have it interpret newlines differently. Unless newlines are
+// written as paragraphs, a final newline at the end of buf is optional.
+//
+// As required by io.Writer, it returns the number of bytes of buf consumed,
+// which is len(buf) unless an error is returned, rather than the number of
+// bytes written to the underlying io.Writer, which includes prefixes and
+// newlines; use BytesWritten for the latter. When an error is returned, the
+// count includes only those lines written in full, except that it is zero
+// when newlines are soft. When the underlying io.Writer accepts fewer bytes
+// than given without an error, io.ErrShortWrite is returned.
+//
+// When streaming, as enabled by SetStreaming, a partial word and a partial line
+// are held until later calls complete them. Because all of buf is held before
+// any of it is written, the count is always len(buf), even when an error is
+// returned.
 func (ww *Writer) Write(buf []byte) (int, error) {
-	var tw int
-	var err error
+	if ww.streaming {
+		return ww.writeStream(buf)
+	}
 
-	pp := strings.Split(string(buf), "\n")
+	text := string(buf)
+	switch ww.newlines {
+	case NewlineHard:
+		if text == "" {
+			return 0, nil
+		}
+		text = strings.TrimSuffix(text, "\n")
+	case NewlineSoft:
+		var rf reflower
+		if text = strings.TrimSuffix(string(rf.end(rf.reflow(nil, buf))), "\n"); text == "" {
+			return len(buf), nil // only blank lines
+		}
+	}
 
-	for _, p := range pp {
-		nw, err := ww.WriteParagraph(p)
-		tw += nw
-		if err != nil {
-			return tw, err
+	var consumed int
+
+	for _, p := range strings.Split(text, "\n") {
+		if err := ww.writeLine(p); err != nil {
+			if ww.newlines == NewlineSoft {
+				consumed = 0 // reflowed text does not correspond to buf
+			}
+			return consumed, err
 		}
+		consumed += len(p) + 1 // newline
 	}
 
-	return tw, err
+	return len(buf), nil
+}
+
+// BytesWritten returns the total number of bytes written to the underlying
+// io.Writer, including prefixes, suffixes, and newlines.
+func (ww *Writer) BytesWritten() int64 {
+	return ww.written
 }
 
 // WriteParagraph writes p to the underlying io.Writer, wrapping lines as
@@ -134,20 +446,9 @@ func (ww *Writer) Write(buf []byte) (int, error) {
 func (ww *Writer) WriteParagraph(p string) (int, error) {
 	debug("WriteParagraph(%q): %q; %d\n", p, string(ww.lb.Bytes()), ww.remaining)
 
-	var tw int // total written
-
-	for _, word := range strings.Fields(p) {
-		nw, err := ww.writeWord(word)
-		tw += nw
-		if err != nil {
-			return tw, err
-		}
-
-		_, err = ww.lb.WriteRune(' ')
-		ww.remaining--
-		if err != nil {
-			return tw, err
-		}
+	tw, err := ww.writeText(p)
+	if err != nil {
+		return tw, err
 	}
 
 	// All words for this paragraph have been written. Write newline to buffer
@@ -161,14 +462,57 @@ func (ww *Writer) WriteParagraph(p string) (int, error) {
 		return tw, err
 	}
 
-	nw, err = ww.newline()
-	tw += nw
-
 	// Do not need to flush again after newline, because we do not want the next
 	// prefix to be flushed yet.
+	nw, err = ww.endParagraph()
+	tw += nw
 	return tw, err
 }
 
+// LineBreak ends the current line without ending the paragraph, writing it to
+// the underlying io.Writer. The trailing space left by WriteWord is removed.
+// When the current line has no text, a blank line is written. The prefix of
+// the next line is not written until text is written to that line.
+func (ww *Writer) LineBreak() (int, error) {
+	return ww.newline()
+}
+
+// ParagraphBreak ends the current paragraph, writing the current line when it
+// has text, followed by a blank line, as WriteParagraph does. The trailing
+// space left by WriteWord is removed, and the prefix of the next line is not
+// written until text is written to that line. Because it only ends a
+// paragraph, it does nothing when no text has been written since the previous
+// paragraph ended. Text held by Write when streaming is not written; see
+// Close.
+func (ww *Writer) ParagraphBreak() (int, error) {
+	if ww.first && ww.lineEmpty() {
+		return 0, nil
+	}
+
+	var tw int
+
+	if !ww.lineEmpty() {
+		nw, err := ww.newline()
+		tw += nw
+		if err != nil {
+			return tw, err
+		}
+	}
+
+	nw, err := ww.endParagraph()
+	tw += nw
+	return tw, err
+}
+
+// writeText writes the words of p, breaking lines as configured, leaving the
+// last line of p in the line buffer.
+func (ww *Writer) writeText(p string) (int, error) {
+	if ww.breaking == BreakOptimal {
+		return ww.writeOptimal(p)
+	}
+	return ww.writeSegments(segments(p))
+}
+
 // WriteRune writes r to the underlying io.Writer, wrapping lines as necessary
 // to prevent line lengths from exceeding the pre-configured width.
 func (ww *Writer) WriteRune(r rune) (int, error) {
@@ -177,22 +521,59 @@ func (ww *Writer) WriteRune(r rune) (int, error) {
 
 	debug("WriteRune(%q): %q; %d\n", r, string(ww.lb.Bytes()), ww.remaining)
 
+	if r == esc || len(ww.esc) > 0 {
+		// Runes of an escape sequence occupy no columns.
+		ww.esc = utf8.AppendRune(ww.esc, r)
+		if isCompleteEscape(string(ww.esc)) {
+			ww.trackEscape(string(ww.esc))
+			ww.esc = ww.esc[:0]
+		}
+		if _, err := ww.lb.WriteRune(r); err != nil {
+			return 0, err
+		}
+		return ww.flush()
+	}
+
 	switch r {
 	case '\n':
 		return ww.newline()
 
+	case '\t':
+		return ww.writeTab()
+
 	default:
-		if ww.remaining < 2 {
-			// Not enough room for r and a newline.
-			if tw, err = ww.newline(); err != nil {
-				return tw, err
+		// A rune that extends the current extended grapheme cluster is always
+		// written on the same line as the rest of the cluster, so a forced
+		// wrap never splits a cluster across lines.
+		extends := !ww.gs.breaks(r)
+		if !extends {
+			ww.cluster = ww.cluster[:0]
+		}
+		before := ww.columns(string(ww.cluster))
+		ww.cluster = utf8.AppendRune(ww.cluster, r)
+		rc := ww.columns(string(ww.cluster)) - before
+
+		if !extends {
+			need := rc
+			if ww.measure == MeasureCells && need < 2 && unicode.Is(extendedPictographic, r) {
+				// Reserve room for an emoji presentation selector that may
+				// follow.
+				need = 2
+			}
+			if need > 0 && ww.remaining < need+1 {
+				// Not enough room for r and a newline.
+				if tw, err = ww.wrap(); err != nil {
+					return tw, err
+				}
+				ww.gs.breaks(r)
+				ww.cluster = utf8.AppendRune(ww.cluster, r)
 			}
 		}
 
 		if _, err := ww.lb.WriteRune(r); err != nil {
 			return tw, err
 		}
-		ww.remaining--
+		ww.remaining -= rc
 
 		nw, err := ww.flush()
 		tw += nw
@@ -203,7 +584,7 @@ func (ww *Writer) WriteRune(r rune) (int, error) {
 // WriteWord writes w to the underlying io.Writer, wrapping lines as necessary
 // to prevent line lengths from exceeding the pre-configured width.
 func (ww *Writer) WriteWord(w string) (int, error) {
-	tw, err := ww.writeWord(w)
+	tw, err := ww.writeSegments(segments(w))
 	if err != nil {
 		return tw, err
 	}
@@ -220,20 +601,72 @@ func (ww *Writer) WriteWord(w string) (int, error) {
 	return tw, err
 }
 
-func (ww *Writer) writeWord(w string) (int, error) {
+// writeSegments writes each segment, wrapping lines as necessary, and follows
+// each segment that ends a word with a space.
+func (ww *Writer) writeSegments(ss []segment) (int, error) {
+	var tw int // total written
+
+	for _, s := range ss {
+		nw, err := ww.writeWord(s.text, s.shy)
+		tw += nw
+		if err != nil {
+			return tw, err
+		}
+
+		if s.space {
+			_, err = ww.lb.WriteRune(' ')
+			ww.remaining--
+			if err != nil {
+				return tw, err
+			}
+		}
+	}
+
+	return tw, nil
+}
+
+// writeWord writes w, wrapping the line first when w does not fit on it. When
+// w may be hyphenated, either at the byte offsets in shy or where the
+// Hyphenator permits, the head of w may fill the rest of the line instead.
+func (ww *Writer) writeWord(w string, shy []int) (int, error) {
 	var err error
 	var tw int // total written
 
 	// Number of columns this word occupies, plus a column for the final space
 	// or newline character.
-	rc := utf8.RuneCountInString(w) + 1
+	rc := ww.columns(w) + 1
 
 	debug("writeWord(%q); rc: %d; %q (remaining: %d)\n", w, rc, string(ww.lb.Bytes()), ww.remaining)
 
+	// The next rune written begins a new extended grapheme cluster.
+	ww.gs = graphemeState{}
+	ww.cluster = ww.cluster[:0]
+
 	// When not enough room for w and a space.
 	if ww.remaining < rc {
-		if tw, err = ww.newline(); err != nil {
-			return tw, err
+		if p, ok := ww.hyphenate(w, shy); ok {
+			// Fill the rest of this line with the head of w, and continue
+			// with its tail on the next line.
+			if _, err = ww.lb.WriteString(w[:p] + "-"); err != nil {
+				return tw, err
+			}
+			ww.remaining -= ww.columns(w[:p]) + 1
+			if tw, err = ww.wrap(); err != nil {
+				return tw, err
+			}
+			nw, err := ww.writeWord(w[p:], shift(shy, p))
+			return tw + nw, err
+		}
+		if ww.longWord != LongWordOverflow && rc-1 > ww.capacity() {
+			return ww.writeLongWord(w)
+		}
+		if !ww.lineEmpty() {
+			// Try again at the start of the next line.
+			if tw, err = ww.wrap(); err != nil {
+				return tw, err
+			}
+			nw, err := ww.writeWord(w, shy)
+			return tw + nw, err
 		}
 	}
 
@@ -241,6 +674,7 @@ func (ww *Writer) writeWord(w string) (int, error) {
 		return tw, err
 	}
 	ww.remaining -= (rc - 1)
+	ww.trackEscapes(w)
 
 	return tw, err
 }
@@ -2,6 +2,9 @@ package golinewrap_test
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
+	"io"
 	"os"
 	"strings"
 	"testing"
@@ -13,8 +16,15 @@ func TestNewReturnsErrorWhenPrefixTooLong(t *testing.T) {
 	prefix := "1234567890"
 	width := len(prefix)
 	_, err := golinewrap.New(os.Stderr, width, prefix)
-	if want := "columns"; err == nil || !strings.Contains(err.Error(), want) {
-		t.Errorf("GOT: %v; WANT: %v", err, want)
+	if want := golinewrap.ErrWidthTooSmall; !errors.Is(err, want) {
+		t.Fatalf("GOT: %v; WANT: %v", err, want)
+	}
+	var we *golinewrap.WidthError
+	if !errors.As(err, &we) {
+		t.Fatalf("GOT: %T; WANT: %T", err, we)
+	}
+	if got, want := we.Columns, len(prefix); got != want {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
 	}
 }
 
@@ -339,3 +349,324 @@ func TestWrite(t *testing.T) {
 		}
 	})
 }
+
+func TestSetFirstLinePrefix(t *testing.T) {
+	t.Run("error when too long", func(t *testing.T) {
+		lw, err := golinewrap.New(new(bytes.Buffer), 8, "  ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.SetFirstLinePrefix("warning: "); err == nil {
+			t.Errorf("GOT: %v; WANT: error", err)
+		}
+	})
+
+	t.Run("hanging indent", func(t *testing.T) {
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, 21, "         ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.SetFirstLinePrefix("warning: "); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = lw.WriteParagraph("disk is almost full"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = lw.WriteParagraph("one two"); err != nil {
+			t.Fatal(err)
+		}
+
+		want := "warning: disk is\n         almost full\n\nwarning: one two\n\n"
+		if got := string(bb.Bytes()); got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("changed for each paragraph", func(t *testing.T) {
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, 30, "                  ")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		options := []struct{ flag, description string }{
+			{"  -v, --verbose   ", "print more output"},
+			{"  -q, --quiet     ", "print nothing"},
+		}
+
+		for _, o := range options {
+			if err = lw.SetFirstLinePrefix(o.flag); err != nil {
+				t.Fatal(err)
+			}
+			if _, err = lw.WriteParagraph(o.description); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		want := "  -v, --verbose   print more\n                  output\n\n  -q, --quiet     print\n                  nothing\n\n"
+		if got := string(bb.Bytes()); got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+}
+
+// shortWriter accepts at most n bytes in total, without returning an error.
+type shortWriter struct{ n int }
+
+func (sw *shortWriter) Write(buf []byte) (int, error) {
+	if len(buf) > sw.n {
+		nw := sw.n
+		sw.n = 0
+		return nw, nil
+	}
+	sw.n -= len(buf)
+	return len(buf), nil
+}
+
+func TestWriteCount(t *testing.T) {
+	t.Run("consumed", func(t *testing.T) {
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, 13, "> ")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s := "One two three four.\nFive six."
+		nw, err := fmt.Fprint(lw, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := nw, len(s); got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		if got, want := lw.BytesWritten(), int64(bb.Len()); got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		if got, want := string(bb.Bytes()), "> One two\n> three\n> four.\n>\n> Five six.\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("short write", func(t *testing.T) {
+		lw, err := golinewrap.New(&shortWriter{n: 15}, 13, "> ")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s := "One two.\nThree four five six."
+		nw, err := lw.Write([]byte(s))
+		if got, want := err, io.ErrShortWrite; got != want {
+			t.Fatalf("GOT: %v; WANT: %v", got, want)
+		}
+		if got, want := nw, len("One two.\n"); got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		if got, want := lw.BytesWritten(), int64(15); got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	})
+}
+
+func TestLineAndParagraphBreak(t *testing.T) {
+	emit := func(t *testing.T, fn func(*golinewrap.Writer) error) string {
+		t.Helper()
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, 16, "> ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = fn(lw); err != nil {
+			t.Fatal(err)
+		}
+
+		return string(bb.Bytes())
+	}
+
+	words := func(lw *golinewrap.Writer, ww ...string) error {
+		for _, w := range ww {
+			if _, err := lw.WriteWord(w); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	t.Run("line break", func(t *testing.T) {
+		got := emit(t, func(lw *golinewrap.Writer) error {
+			if err := words(lw, "one", "two"); err != nil {
+				return err
+			}
+			if _, err := lw.LineBreak(); err != nil {
+				return err
+			}
+			return words(lw, "three")
+		})
+		if want := "> one two\n> three"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("line break without text", func(t *testing.T) {
+		got := emit(t, func(lw *golinewrap.Writer) error {
+			_, err := lw.LineBreak()
+			return err
+		})
+		if want := ">\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("paragraph break", func(t *testing.T) {
+		got := emit(t, func(lw *golinewrap.Writer) error {
+			if err := words(lw, "one"); err != nil {
+				return err
+			}
+			for i := 0; i < 2; i++ {
+				if _, err := lw.ParagraphBreak(); err != nil {
+					return err
+				}
+			}
+			return words(lw, "two")
+		})
+		if want := "> one\n>\n> two"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("paragraph break after line break", func(t *testing.T) {
+		got := emit(t, func(lw *golinewrap.Writer) error {
+			if err := words(lw, "one"); err != nil {
+				return err
+			}
+			if _, err := lw.LineBreak(); err != nil {
+				return err
+			}
+			_, err := lw.ParagraphBreak()
+			return err
+		})
+		if want := "> one\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("paragraph break without text", func(t *testing.T) {
+		got := emit(t, func(lw *golinewrap.Writer) error {
+			_, err := lw.ParagraphBreak()
+			return err
+		})
+		if want := ""; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+}
+
+func TestSetWidth(t *testing.T) {
+	t.Run("next line", func(t *testing.T) {
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, 10, "> ")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = lw.WriteWord("one"); err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.SetWidth(20); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = lw.WriteParagraph("two three four five six seven"); err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := string(bb.Bytes()), "> one two\n> three four five\n> six seven\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("empty line", func(t *testing.T) {
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, 20, "> ")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = lw.SetWidth(10); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = lw.WriteParagraph("one two three"); err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := string(bb.Bytes()), "> one two\n> three\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("pending width validates setters", func(t *testing.T) {
+		lw, err := golinewrap.New(new(bytes.Buffer), 20, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = lw.WriteWord("one"); err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.SetWidth(6); err != nil {
+			t.Fatal(err)
+		}
+
+		if err = lw.SetFirstLinePrefix("warning: "); !errors.Is(err, golinewrap.ErrWidthTooSmall) {
+			t.Errorf("GOT: %v; WANT: %v", err, golinewrap.ErrWidthTooSmall)
+		}
+		if err = lw.SetSuffix(" | end |"); !errors.Is(err, golinewrap.ErrWidthTooSmall) {
+			t.Errorf("GOT: %v; WANT: %v", err, golinewrap.ErrWidthTooSmall)
+		}
+		if err = lw.SetPrefix("123456"); !errors.Is(err, golinewrap.ErrWidthTooSmall) {
+			t.Errorf("GOT: %v; WANT: %v", err, golinewrap.ErrWidthTooSmall)
+		}
+	})
+
+	t.Run("pending width validates measure", func(t *testing.T) {
+		lw, err := golinewrap.NewWithOptions(new(bytes.Buffer),
+			golinewrap.WithWidth(20),
+			golinewrap.WithPrefix("世界世"),
+			golinewrap.WithMeasure(golinewrap.MeasureRunes),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = lw.WriteWord("one"); err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.SetWidth(6); err != nil {
+			t.Fatal(err)
+		}
+
+		// Prefix uses 3 runes, but 6 cells.
+		if err = lw.SetMeasure(golinewrap.MeasureCells); !errors.Is(err, golinewrap.ErrWidthTooSmall) {
+			t.Errorf("GOT: %v; WANT: %v", err, golinewrap.ErrWidthTooSmall)
+		}
+	})
+
+	t.Run("too small", func(t *testing.T) {
+		lw, err := golinewrap.New(new(bytes.Buffer), 20, "> ")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, width := range []int{0, 2} {
+			if err = lw.SetWidth(width); !errors.Is(err, golinewrap.ErrWidthTooSmall) {
+				t.Errorf("GOT: %v; WANT: %v", err, golinewrap.ErrWidthTooSmall)
+			}
+		}
+	})
+}
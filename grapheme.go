@@ -0,0 +1,291 @@
+package golinewrap
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// gbProperty is the Grapheme_Cluster_Break property of a rune, as defined by
+// Unicode Standard Annex #29.
+type gbProperty int
+
+const (
+	gbOther gbProperty = iota
+	gbCR
+	gbLF
+	gbControl
+	gbExtend
+	gbZWJ
+	gbRegionalIndicator
+	gbPrepend
+	gbSpacingMark
+	gbL
+	gbV
+	gbT
+	gbLV
+	gbLVT
+)
+
+// extendedPictographic contains the runes with the Extended_Pictographic
+// property, derived from the Unicode emoji-data.txt data file.
+var extendedPictographic = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x00a9, 0x00a9, 1},
+		{0x00ae, 0x00ae, 1},
+		{0x203c, 0x203c, 1},
+		{0x2049, 0x2049, 1},
+		{0x2122, 0x2122, 1},
+		{0x2139, 0x2139, 1},
+		{0x2194, 0x2199, 1},
+		{0x21a9, 0x21aa, 1},
+		{0x231a, 0x231b, 1},
+		{0x2328, 0x2328, 1},
+		{0x2388, 0x2388, 1},
+		{0x23cf, 0x23cf, 1},
+		{0x23e9, 0x23f3, 1},
+		{0x23f8, 0x23fa, 1},
+		{0x24c2, 0x24c2, 1},
+		{0x25aa, 0x25ab, 1},
+		{0x25b6, 0x25b6, 1},
+		{0x25c0, 0x25c0, 1},
+		{0x25fb, 0x25fe, 1},
+		{0x2600, 0x2605, 1},
+		{0x2607, 0x2612, 1},
+		{0x2614, 0x2685, 1},
+		{0x2690, 0x2705, 1},
+		{0x2708, 0x2712, 1},
+		{0x2714, 0x2714, 1},
+		{0x2716, 0x2716, 1},
+		{0x271d, 0x271d, 1},
+		{0x2721, 0x2721, 1},
+		{0x2728, 0x2728, 1},
+		{0x2733, 0x2734, 1},
+		{0x2744, 0x2744, 1},
+		{0x2747, 0x2747, 1},
+		{0x274c, 0x274c, 1},
+		{0x274e, 0x274e, 1},
+		{0x2753, 0x2755, 1},
+		{0x2757, 0x2757, 1},
+		{0x2763, 0x2767, 1},
+		{0x2795, 0x2797, 1},
+		{0x27a1, 0x27a1, 1},
+		{0x27b0, 0x27b0, 1},
+		{0x27bf, 0x27bf, 1},
+		{0x2934, 0x2935, 1},
+		{0x2b05, 0x2b07, 1},
+		{0x2b1b, 0x2b1c, 1},
+		{0x2b50, 0x2b50, 1},
+		{0x2b55, 0x2b55, 1},
+		{0x3030, 0x3030, 1},
+		{0x303d, 0x303d, 1},
+		{0x3297, 0x3297, 1},
+		{0x3299, 0x3299, 1},
+	},
+	R32: []unicode.Range32{
+		{0x1f000, 0x1f0ff, 1},
+		{0x1f10d, 0x1f10f, 1},
+		{0x1f12f, 0x1f12f, 1},
+		{0x1f16c, 0x1f171, 1},
+		{0x1f17e, 0x1f17f, 1},
+		{0x1f18e, 0x1f18e, 1},
+		{0x1f191, 0x1f19a, 1},
+		{0x1f1ad, 0x1f1e5, 1},
+		{0x1f201, 0x1f20f, 1},
+		{0x1f21a, 0x1f21a, 1},
+		{0x1f22f, 0x1f22f, 1},
+		{0x1f232, 0x1f23a, 1},
+		{0x1f23c, 0x1f23f, 1},
+		{0x1f249, 0x1f3fa, 1},
+		{0x1f400, 0x1f53d, 1},
+		{0x1f546, 0x1f64f, 1},
+		{0x1f680, 0x1f6ff, 1},
+		{0x1f774, 0x1f77f, 1},
+		{0x1f7d5, 0x1f7ff, 1},
+		{0x1f80c, 0x1f80f, 1},
+		{0x1f848, 0x1f84f, 1},
+		{0x1f85a, 0x1f85f, 1},
+		{0x1f888, 0x1f88f, 1},
+		{0x1f8ae, 0x1f8ff, 1},
+		{0x1f90c, 0x1f93a, 1},
+		{0x1f93c, 0x1f945, 1},
+		{0x1f947, 0x1faff, 1},
+		{0x1fc00, 0x1fffd, 1},
+	},
+}
+
+// prepend contains the runes with the Prepend grapheme cluster break property
+// that are not prepended concatenation marks.
+var prepend = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x0d4e, 0x0d4e, 1},
+	},
+	R32: []unicode.Range32{
+		{0x111c2, 0x111c3, 1},
+		{0x1193f, 0x1193f, 1},
+		{0x11941, 0x11941, 1},
+		{0x11a3a, 0x11a3a, 1},
+		{0x11a84, 0x11a89, 1},
+		{0x11d46, 0x11d46, 1},
+		{0x11f02, 0x11f02, 1},
+	},
+}
+
+// graphemeProperty returns the Grapheme_Cluster_Break property of r.
+func graphemeProperty(r rune) gbProperty {
+	switch {
+	case r == '\r':
+		return gbCR
+	case r == '\n':
+		return gbLF
+	case r < 0x20 || (r >= 0x7f && r < 0xa0):
+		return gbControl
+	case r < 0x300:
+		if r == 0xad {
+			return gbControl // SOFT HYPHEN
+		}
+		return gbOther // fast path for Latin text
+	case r == 0x200d:
+		return gbZWJ
+	case r >= 0x1100 && r <= 0x115f, r >= 0xa960 && r <= 0xa97c:
+		return gbL
+	case r >= 0x1160 && r <= 0x11a7, r >= 0xd7b0 && r <= 0xd7c6:
+		return gbV
+	case r >= 0x11a8 && r <= 0x11ff, r >= 0xd7cb && r <= 0xd7fb:
+		return gbT
+	case r >= 0xac00 && r <= 0xd7a3:
+		if (r-0xac00)%28 == 0 {
+			return gbLV
+		}
+		return gbLVT
+	case r >= 0x1f3fb && r <= 0x1f3ff, r >= 0xe0020 && r <= 0xe007f:
+		return gbExtend // emoji modifiers and tags
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Other_Grapheme_Extend):
+		return gbExtend
+	case unicode.Is(unicode.Regional_Indicator, r):
+		return gbRegionalIndicator
+	case unicode.In(r, unicode.Prepended_Concatenation_Mark, prepend):
+		return gbPrepend
+	case unicode.In(r, unicode.Cc, unicode.Cf, unicode.Zl, unicode.Zp):
+		if r == 0x200c {
+			return gbExtend // ZERO WIDTH NON-JOINER
+		}
+		return gbControl
+	case unicode.Is(unicode.Mc, r), r == 0x0e33, r == 0x0eb3:
+		return gbSpacingMark
+	default:
+		return gbOther
+	}
+}
+
+// graphemeState tracks the context required to determine whether there is an
+// extended grapheme cluster boundary before the next rune.
+type graphemeState struct {
+	started bool       // whether any rune has been seen
+	prev    gbProperty // property of the previous rune
+	pict    bool       // previous runes are Extended_Pictographic Extend*
+	pictZWJ bool       // previous runes are Extended_Pictographic Extend* ZWJ
+	ri      int        // number of consecutive regional indicators
+}
+
+// breaks returns true when there is an extended grapheme cluster boundary
+// before r, then updates the state to include r.
+func (gs *graphemeState) breaks(r rune) bool {
+	p := graphemeProperty(r)
+	b := gs.boundary(p, r)
+
+	if p == gbRegionalIndicator {
+		gs.ri++
+	} else {
+		gs.ri = 0
+	}
+	switch {
+	case unicode.Is(extendedPictographic, r):
+		gs.pict, gs.pictZWJ = true, false
+	case p == gbExtend && gs.pict:
+		// remain within the emoji sequence
+	case p == gbZWJ && gs.pict:
+		gs.pict, gs.pictZWJ = false, true
+	default:
+		gs.pict, gs.pictZWJ = false, false
+	}
+	gs.started = true
+	gs.prev = p
+
+	return b
+}
+
+func (gs *graphemeState) boundary(p gbProperty, r rune) bool {
+	if !gs.started {
+		return true // GB1
+	}
+	switch {
+	case gs.prev == gbCR && p == gbLF:
+		return false // GB3
+	case gs.prev == gbCR || gs.prev == gbLF || gs.prev == gbControl:
+		return true // GB4
+	case p == gbCR || p == gbLF || p == gbControl:
+		return true // GB5
+	case gs.prev == gbL && (p == gbL || p == gbV || p == gbLV || p == gbLVT):
+		return false // GB6
+	case (gs.prev == gbLV || gs.prev == gbV) && (p == gbV || p == gbT):
+		return false // GB7
+	case (gs.prev == gbLVT || gs.prev == gbT) && p == gbT:
+		return false // GB8
+	case p == gbExtend || p == gbZWJ:
+		return false // GB9
+	case p == gbSpacingMark:
+		return false // GB9a
+	case gs.prev == gbPrepend:
+		return false // GB9b
+	case gs.pictZWJ && unicode.Is(extendedPictographic, r):
+		return false // GB11
+	case gs.prev == gbRegionalIndicator && p == gbRegionalIndicator:
+		return gs.ri%2 == 0 // GB12 and GB13
+	default:
+		return true // GB999
+	}
+}
+
+// nextGrapheme returns the first extended grapheme cluster in s along with the
+// remainder of s.
+func nextGrapheme(s string) (string, string) {
+	var gs graphemeState
+	for i, r := range s {
+		if gs.breaks(r) && i > 0 {
+			return s[:i], s[i:]
+		}
+	}
+	return s, ""
+}
+
+// graphemeWidth returns the number of terminal cells required to display the
+// extended grapheme cluster g. The width of a cluster is the width of its
+// first visible rune, except that emoji presentation sequences and flags are
+// always displayed two cells wide.
+func graphemeWidth(g string) int {
+	r, size := utf8.DecodeRuneInString(g)
+	w := runeWidth(r)
+
+	for w == 0 && graphemeProperty(r) == gbPrepend && size < len(g) {
+		// Prepended marks are displayed along with the rune that follows.
+		var n int
+		r, n = utf8.DecodeRuneInString(g[size:])
+		w = runeWidth(r)
+		size += n
+	}
+
+	switch {
+	case w == 0 && (r >= 0x1160 && r <= 0x11ff || r >= 0xd7b0 && r <= 0xd7ff):
+		return 1 // Hangul medial vowel or final consonant without a leading consonant
+	case unicode.Is(unicode.Regional_Indicator, r):
+		return 2
+	case w == 1 && unicode.Is(extendedPictographic, r):
+		for _, r = range g[size:] {
+			if r == 0xfe0f {
+				return 2 // VARIATION SELECTOR-16 requests emoji presentation
+			}
+		}
+	}
+
+	return w
+}
@@ -0,0 +1,82 @@
+package golinewrap_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/karrick/golinewrap"
+)
+
+func TestGraphemeClusters(t *testing.T) {
+	const family = "\U0001F468\u200d\U0001F469\u200d\U0001F467" // man, woman, girl
+	const flag = "\U0001F1FA\U0001F1F8"                         // United States
+	const thumbs = "\U0001F44D\U0001F3FD"                       // thumbs up, medium skin tone
+	const heart = "\u2764\ufe0f"                                // heart, emoji presentation
+
+	emitRunes := func(t *testing.T, width int, prefix string, s string) string {
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, width, prefix)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, r := range s {
+			if _, err = lw.WriteRune(r); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		return string(bb.Bytes())
+	}
+
+	emitParagraph := func(t *testing.T, width int, prefix string, p string) string {
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, width, prefix)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = lw.WriteParagraph(p); err != nil {
+			t.Fatal(err)
+		}
+
+		return string(bb.Bytes())
+	}
+
+	t.Run("WriteRune does not split ZWJ sequence", func(t *testing.T) {
+		got := emitRunes(t, 6, ">", "ab"+family+family)
+		if want := ">ab" + family + "\n>" + family; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("WriteRune does not split flag", func(t *testing.T) {
+		got := emitRunes(t, 5, ">", "ab"+flag+flag)
+		if want := ">ab\n>" + flag + "\n>" + flag; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("WriteRune does not split skin tone modifier", func(t *testing.T) {
+		got := emitRunes(t, 6, ">", "abc"+thumbs)
+		if want := ">abc\n>" + thumbs; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("WriteRune reserves room for variation selector", func(t *testing.T) {
+		got := emitRunes(t, 6, ">", "abc"+heart)
+		if want := ">abc\n>" + heart; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("cluster measured as one unit", func(t *testing.T) {
+		got := emitParagraph(t, 8, ">", family+" "+flag+" "+thumbs)
+		if want := ">" + family + " " + flag + "\n>" + thumbs + "\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+}
@@ -0,0 +1,271 @@
+package golinewrap
+
+import (
+	"bufio"
+	_ "embed" // for embedded English hyphenation patterns
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+//go:embed hyph-en-us.pat.txt
+var englishPatterns string
+
+//go:embed hyph-en-us.hyp.txt
+var englishExceptions string
+
+var (
+	english     *Hyphenator
+	englishOnce sync.Once
+)
+
+// English returns a Hyphenator for American English, using the patterns and
+// exceptions Frank Liang prepared for TeX. See hyph-en-us.lic.txt for their
+// provenance and licence.
+func English() *Hyphenator {
+	englishOnce.Do(func() {
+		h, err := NewHyphenator(strings.NewReader(englishPatterns))
+		if err != nil {
+			panic(err) // embedded patterns are known to be valid
+		}
+		if err = h.LoadExceptions(strings.NewReader(englishExceptions)); err != nil {
+			panic(err)
+		}
+		h.leftMin, h.rightMin = 2, 3
+		english = h
+	})
+	return english
+}
+
+// Hyphenator finds the points at which the words of a single language may be
+// hyphenated, using the algorithm Frank Liang developed for TeX.
+type Hyphenator struct {
+	patterns   map[string][]byte // pattern letters -> value before each letter and after the last
+	exceptions map[string][]int  // lowercase word -> rune offsets of its hyphens
+	maxLength  int               // number of letters in longest pattern
+	leftMin    int               // minimum number of letters before a hyphen
+	rightMin   int               // minimum number of letters after a hyphen
+}
+
+// NewHyphenator returns a Hyphenator that uses the patterns read from r, which
+// is expected to be in the format of the TeX `hyph-*.pat.txt` files: patterns
+// separated by white space, each pattern being lowercase letters interleaved
+// with digits, with a period marking the start or end of a word. Text after a
+// percent sign is treated as a comment and ignored.
+func NewHyphenator(r io.Reader) (*Hyphenator, error) {
+	h := &Hyphenator{
+		patterns:   make(map[string][]byte),
+		exceptions: make(map[string][]int),
+		leftMin:    2,
+		rightMin:   2,
+	}
+
+	err := scanTokens(r, func(token string) error {
+		var letters []rune
+		values := []byte{0}
+
+		for _, r := range token {
+			if r >= '0' && r <= '9' {
+				values[len(values)-1] = byte(r - '0')
+				continue
+			}
+			letters = append(letters, unicode.ToLower(r))
+			values = append(values, 0)
+		}
+		if len(letters) == 0 {
+			return fmt.Errorf("cannot parse hyphenation pattern without letters: %q", token)
+		}
+
+		h.patterns[string(letters)] = values
+		if len(letters) > h.maxLength {
+			h.maxLength = len(letters)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
+// LoadExceptions reads words from r that are to be hyphenated exactly as
+// given, rather than by the patterns. The expected format is that of the TeX
+// `hyph-*.hyp.txt` files: words separated by white space, with a hyphen at
+// each point the word may be broken. A word without hyphens is never broken.
+func (h *Hyphenator) LoadExceptions(r io.Reader) error {
+	return scanTokens(r, func(token string) error {
+		h.AddException(token)
+		return nil
+	})
+}
+
+// AddException causes word to be hyphenated exactly as given, with a hyphen
+// at each point the word may be broken, rather than by the patterns.
+func (h *Hyphenator) AddException(word string) {
+	var letters []rune
+	var points []int
+
+	for _, r := range word {
+		if r == '-' {
+			points = append(points, len(letters))
+			continue
+		}
+		letters = append(letters, unicode.ToLower(r))
+	}
+
+	h.exceptions[string(letters)] = points
+}
+
+// SetMinimums changes the minimum number of letters that must remain before
+// and after a hyphen. It returns an error unless both are greater than zero.
+func (h *Hyphenator) SetMinimums(left, right int) error {
+	if left <= 0 || right <= 0 {
+		return fmt.Errorf("cannot set hyphenation minimums unless both left (%d) and right (%d) are greater than zero.", left, right)
+	}
+	h.leftMin, h.rightMin = left, right
+	return nil
+}
+
+// Hyphenate returns the byte offsets in word at which a hyphen may be
+// inserted. It returns nil when word contains anything other than letters.
+func (h *Hyphenator) Hyphenate(word string) []int {
+	letters := make([]rune, 0, len(word))
+	for _, r := range word {
+		if !unicode.IsLetter(r) {
+			return nil
+		}
+		letters = append(letters, unicode.ToLower(r))
+	}
+
+	points, ok := h.exceptions[string(letters)]
+	if !ok {
+		points = h.points(letters)
+	}
+
+	// Convert rune offsets to byte offsets.
+	var offsets []int
+	var i, ri int
+	for _, p := range points {
+		if p < h.leftMin || p > len(letters)-h.rightMin {
+			continue
+		}
+		for ; ri < p; ri++ {
+			_, size := utf8.DecodeRuneInString(word[i:])
+			i += size
+		}
+		offsets = append(offsets, i)
+	}
+
+	return offsets
+}
+
+// points returns the rune offsets in letters at which the patterns allow a
+// hyphen, in increasing order.
+func (h *Hyphenator) points(letters []rune) []int {
+	// Surround the word with periods so patterns may match either end.
+	w := make([]rune, 0, len(letters)+2)
+	w = append(w, '.')
+	w = append(w, letters...)
+	w = append(w, '.')
+
+	values := make([]byte, len(w)+1)
+
+	for i := range w {
+		for j := i + 1; j <= len(w) && j-i <= h.maxLength; j++ {
+			pv, ok := h.patterns[string(w[i:j])]
+			if !ok {
+				continue
+			}
+			for k, v := range pv {
+				if v > values[i+k] {
+					values[i+k] = v
+				}
+			}
+		}
+	}
+
+	// An odd value before a letter permits a hyphen there. Skip the leading
+	// period when converting to offsets in letters.
+	var points []int
+	for p := 1; p < len(letters); p++ {
+		if values[p+1]%2 == 1 {
+			points = append(points, p)
+		}
+	}
+
+	return points
+}
+
+// scanTokens invokes fn with each white space separated token read from r,
+// ignoring text from a percent sign to the end of its line.
+func scanTokens(r io.Reader, fn func(string) error) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '%'); i >= 0 {
+			line = line[:i]
+		}
+		for _, token := range strings.Fields(line) {
+			if err := fn(token); err != nil {
+				return err
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// SetHyphenator causes the Writer to hyphenate a word that does not fit on the
+// current line when the Hyphenator finds a point at which the word may be
+// broken that lets part of it fit. A nil Hyphenator, the default, disables
+// hyphenation.
+func (ww *Writer) SetHyphenator(h *Hyphenator) {
+	ww.hyphenator = h
+}
+
+// hyphenate returns the last of the byte offsets in points at which w may be
+// split such that the head and a hyphen fit on the current line. When points
+// is empty, the points the Hyphenator permits are used instead. It returns
+// false when w cannot be so split.
+func (ww *Writer) hyphenate(w string, points []int) (int, bool) {
+	if len(points) == 0 {
+		points = ww.hyphenationPoints(w)
+	}
+	for i := len(points) - 1; i >= 0; i-- {
+		p := points[i]
+		// Room for head, hyphen, and newline.
+		if ww.columns(w[:p])+2 <= ww.remaining {
+			return p, true
+		}
+	}
+
+	return 0, false
+}
+
+// hyphenationPoints returns the byte offsets in w at which the Hyphenator of
+// the Writer permits a hyphen. Leading and trailing punctuation is not
+// considered part of the word, and words with escape sequences are never
+// split.
+func (ww *Writer) hyphenationPoints(w string) []int {
+	if ww.hyphenator == nil || strings.IndexByte(w, esc) >= 0 {
+		return nil
+	}
+
+	start := strings.IndexFunc(w, unicode.IsLetter)
+	if start < 0 {
+		return nil
+	}
+	end := strings.LastIndexFunc(w, unicode.IsLetter)
+	_, size := utf8.DecodeRuneInString(w[end:])
+	end += size
+
+	points := ww.hyphenator.Hyphenate(w[start:end])
+	for i := range points {
+		points[i] += start
+	}
+
+	return points
+}
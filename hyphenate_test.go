@@ -0,0 +1,118 @@
+package golinewrap_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/karrick/golinewrap"
+)
+
+func TestHyphenate(t *testing.T) {
+	hyphenated := func(h *golinewrap.Hyphenator, w string) string {
+		var b strings.Builder
+		var last int
+		for _, p := range h.Hyphenate(w) {
+			b.WriteString(w[last:p])
+			b.WriteString("-")
+			last = p
+		}
+		b.WriteString(w[last:])
+		return b.String()
+	}
+
+	t.Run("English patterns", func(t *testing.T) {
+		for _, want := range []string{"hy-phen-ation", "com-puter", "al-go-rithm", "type-set-ting", "Hy-phen-ation"} {
+			if got := hyphenated(golinewrap.English(), strings.Replace(want, "-", "", -1)); got != want {
+				t.Errorf("GOT: %q; WANT: %q", got, want)
+			}
+		}
+	})
+
+	t.Run("English exceptions", func(t *testing.T) {
+		for _, want := range []string{"ta-ble", "present", "as-so-ciate"} {
+			if got := hyphenated(golinewrap.English(), strings.Replace(want, "-", "", -1)); got != want {
+				t.Errorf("GOT: %q; WANT: %q", got, want)
+			}
+		}
+	})
+
+	t.Run("not a word", func(t *testing.T) {
+		if got := golinewrap.English().Hyphenate("hyphen-ation"); got != nil {
+			t.Errorf("GOT: %v; WANT: %v", got, nil)
+		}
+	})
+
+	t.Run("custom patterns", func(t *testing.T) {
+		h, err := golinewrap.NewHyphenator(strings.NewReader("% comment\n1na\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := hyphenated(h, "banana"), "ba-na-na"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+		h.AddException("ban-ana")
+		if got, want := hyphenated(h, "banana"), "ban-ana"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		if _, err := golinewrap.NewHyphenator(strings.NewReader("1na 12")); err == nil {
+			t.Errorf("GOT: %v; WANT: error", err)
+		}
+	})
+}
+
+func TestSetHyphenator(t *testing.T) {
+	emit := func(t *testing.T, width int, prefix string, p string) string {
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, width, prefix)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lw.SetHyphenator(golinewrap.English())
+
+		if _, err = lw.WriteParagraph(p); err != nil {
+			t.Fatal(err)
+		}
+
+		return string(bb.Bytes())
+	}
+
+	t.Run("hyphenates to fill line", func(t *testing.T) {
+		got := emit(t, 15, ">", "the hyphenation rules")
+		if want := ">the hyphen-\n>ation rules\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("punctuation kept with word", func(t *testing.T) {
+		got := emit(t, 15, ">", "the (hyphenation), rules")
+		if want := ">the (hyphen-\n>ation), rules\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("hyphenates again when tail too wide", func(t *testing.T) {
+		got := emit(t, 10, ">", "the hyphenation")
+		if want := ">the hy-\n>phen-\n>ation\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("wraps when no point fits", func(t *testing.T) {
+		got := emit(t, 10, ">", "the rhythm")
+		if want := ">the\n>rhythm\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("word fits without hyphenation", func(t *testing.T) {
+		got := emit(t, 20, ">", "the hyphenation")
+		if want := ">the hyphenation\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package golinewrap
+
+import (
+	"errors"
+	"strings"
+)
+
+// indentLevel is a level of indentation pushed by Indent.
+type indentLevel struct {
+	segment  string // written after prefix and segments of outer levels
+	width    int    // width in effect before level was pushed
+	widthSet bool   // whether SetWidth was called while level was innermost
+}
+
+// Indent pushes a level of indentation, causing s to be written after the
+// prefix of each line, along with the segments of any outer levels, so nested
+// structures such as errors with causes may be written with a single Writer.
+// Text at each level wraps within the columns left after its indentation, and
+// SetWidth may be used to give a level its own width, which lasts until the
+// level is popped by Dedent. Like SetPrefix, it takes effect with the next
+// line, or with the current one when nothing has been written to it yet. It
+// returns a WidthError unless the width is greater than the number of columns
+// used by each prefix, the indentation, and the suffix.
+func (ww *Writer) Indent(s string) error {
+	width := ww.width()
+	columns := ww.indentColumns + measure(ww.measure, s)
+
+	for _, prefixColumns := range []int{ww.prefixColumns, ww.firstColumns} {
+		if width <= prefixColumns+columns+ww.suffixColumns {
+			return &WidthError{Op: "indent", Width: width, Used: "prefix, indentation, and suffix", Columns: prefixColumns + columns + ww.suffixColumns}
+		}
+	}
+
+	ww.indents = append(ww.indents, indentLevel{segment: s, width: width})
+	ww.setIndent()
+
+	return ww.rewritePrefix()
+}
+
+// Dedent pops the level of indentation most recently pushed by Indent. When
+// SetWidth was called while that level was the innermost one, the width in
+// effect before it was pushed is restored; otherwise the width is left alone,
+// so changes such as terminal resizes are kept. Like SetPrefix, it takes
+// effect with the next line, or with the current one when nothing has been
+// written to it yet. It returns an error unless a level has been pushed, and a
+// WidthError when the width to be restored is too small for the prefix, the
+// remaining indentation, and the suffix, in which case the level is kept.
+func (ww *Writer) Dedent() error {
+	n := len(ww.indents)
+	if n == 0 {
+		return errors.New("cannot dedent unless indented")
+	}
+
+	level := ww.indents[n-1]
+	if level.widthSet {
+		indentColumns := measure(ww.measure, joinIndents(ww.indents[:n-1]))
+		if err := ww.checkWidth(level.width, indentColumns); err != nil {
+			return err
+		}
+	}
+
+	ww.indents = ww.indents[:n-1]
+	ww.setIndent()
+
+	if err := ww.rewritePrefix(); err != nil {
+		return err
+	}
+	if !level.widthSet {
+		return nil
+	}
+	return ww.setWidth(level.width)
+}
+
+// setIndent joins the segments of each level of indentation.
+func (ww *Writer) setIndent() {
+	ww.indent = joinIndents(ww.indents)
+	ww.indentColumns = measure(ww.measure, ww.indent)
+}
+
+// joinIndents returns the segments of levels joined together.
+func joinIndents(levels []indentLevel) string {
+	var b strings.Builder
+	for _, level := range levels {
+		b.WriteString(level.segment)
+	}
+	return b.String()
+}
@@ -0,0 +1,193 @@
+package golinewrap_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/karrick/golinewrap"
+)
+
+func TestIndent(t *testing.T) {
+	t.Run("nested", func(t *testing.T) {
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, 20, "# ")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		steps := []func() error{
+			func() error { _, err := lw.WriteParagraph("cannot open config"); return err },
+			func() error { return lw.Indent("  ") },
+			func() error { _, err := lw.WriteParagraph("caused by: permission denied"); return err },
+			func() error { return lw.Indent("| ") },
+			func() error { _, err := lw.WriteParagraph("one two three four"); return err },
+			func() error { return lw.Dedent() },
+			func() error { return lw.Dedent() },
+			func() error { _, err := lw.WriteParagraph("done"); return err },
+		}
+		for _, step := range steps {
+			if err = step(); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		want := "# cannot open\n# config\n#\n#   caused by:\n#   permission\n#   denied\n#\n#   | one two three\n#   | four\n#   |\n# done\n#\n"
+		if got := string(bb.Bytes()); got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("blank lines without trailing spaces", func(t *testing.T) {
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, 20, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		steps := []func() error{
+			func() error { return lw.Indent(" ") },
+			func() error { _, err := lw.WriteParagraph("one"); return err },
+			func() error { return lw.Indent("  ") },
+			func() error { _, err := lw.WriteParagraph("two"); return err },
+			func() error { _, err := lw.WriteParagraph("three"); return err },
+		}
+		for _, step := range steps {
+			if err = step(); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if got, want := string(bb.Bytes()), " one\n\n   two\n\n   three\n\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("next line", func(t *testing.T) {
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, 12, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = lw.WriteWord("one"); err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.Indent("    "); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = lw.WriteParagraph("two three"); err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := string(bb.Bytes()), "one two\n    three\n\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("level width", func(t *testing.T) {
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, 20, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = lw.Indent("> "); err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.SetWidth(12); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = lw.WriteParagraph("one two three"); err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.Dedent(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = lw.WriteParagraph("four five six seven"); err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := string(bb.Bytes()), "> one two\n> three\n>\nfour five six seven\n\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("width kept unless level set it", func(t *testing.T) {
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, 20, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = lw.Indent("> "); err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.SetWidth(12); err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.Indent("  "); err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.Dedent(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = lw.WriteParagraph("one two three"); err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := string(bb.Bytes()), "> one two\n> three\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("dedent validates before popping", func(t *testing.T) {
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, 20, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = lw.Indent("  "); err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.SetWidth(40); err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.SetPrefix("12345678901234567890"); err != nil {
+			t.Fatal(err)
+		}
+
+		// Restoring a width of 20 leaves no room for the prefix.
+		if err = lw.Dedent(); !errors.Is(err, golinewrap.ErrWidthTooSmall) {
+			t.Errorf("GOT: %v; WANT: %v", err, golinewrap.ErrWidthTooSmall)
+		}
+		if _, err = lw.WriteParagraph("one"); err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := string(bb.Bytes()), "12345678901234567890  one\n12345678901234567890\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		lw, err := golinewrap.New(new(bytes.Buffer), 8, "1234")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = lw.Dedent(); err == nil {
+			t.Errorf("GOT: %v; WANT: %v", err, "error")
+		}
+		if err = lw.Indent("5678"); !errors.Is(err, golinewrap.ErrWidthTooSmall) {
+			t.Errorf("GOT: %v; WANT: %v", err, golinewrap.ErrWidthTooSmall)
+		}
+	})
+}
@@ -0,0 +1,1156 @@
+package golinewrap
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// lbClass is a line breaking class from Unicode Standard Annex #14, Unicode
+// Line Breaking Algorithm. Classes the algorithm resolves to other classes
+// before applying its rules, such as AI, SA, and CJ, are resolved in the table
+// and do not appear here.
+type lbClass uint8
+
+const (
+	lbAL  lbClass = iota // alphabetic
+	lbB2                 // break opportunity before and after
+	lbBA                 // break after
+	lbBB                 // break before
+	lbBK                 // mandatory break
+	lbCB                 // contingent break opportunity
+	lbCL                 // close punctuation
+	lbCM                 // combining mark
+	lbCP                 // close parenthesis
+	lbCR                 // carriage return
+	lbEB                 // emoji base
+	lbEM                 // emoji modifier
+	lbEX                 // exclamation or interrogation
+	lbGL                 // non-breaking glue
+	lbH2                 // Hangul LV syllable
+	lbH3                 // Hangul LVT syllable
+	lbHL                 // Hebrew letter
+	lbHY                 // hyphen
+	lbID                 // ideographic
+	lbIN                 // inseparable
+	lbIS                 // infix numeric separator
+	lbJL                 // Hangul L jamo
+	lbJT                 // Hangul T jamo
+	lbJV                 // Hangul V jamo
+	lbLF                 // line feed
+	lbNL                 // next line
+	lbNS                 // nonstarter
+	lbNU                 // numeric
+	lbOP                 // open punctuation
+	lbPO                 // postfix numeric
+	lbPR                 // prefix numeric
+	lbQU                 // quotation
+	lbRI                 // regional indicator
+	lbSP                 // space
+	lbSY                 // symbols allowing break after
+	lbWJ                 // word joiner
+	lbZW                 // zero width space
+	lbZWJ                // zero width joiner
+)
+
+// lbRange assigns a line breaking class to a range of runes.
+type lbRange struct {
+	lo, hi rune
+	class  lbClass
+}
+
+// lineBreakClass returns the line breaking class of r. Runes not listed in
+// lbTable are Hangul syllables, combining marks, decimal digits, or
+// alphabetic.
+func lineBreakClass(r rune) lbClass {
+	i := sort.Search(len(lbTable), func(i int) bool { return lbTable[i].hi >= r })
+	if i < len(lbTable) && lbTable[i].lo <= r {
+		return lbTable[i].class
+	}
+
+	switch {
+	case r >= 0xAC00 && r <= 0xD7A3:
+		if (r-0xAC00)%28 == 0 {
+			return lbH2
+		}
+		return lbH3
+	case unicode.In(r, unicode.Mn, unicode.Mc, unicode.Me):
+		return lbCM
+	case unicode.Is(unicode.Nd, r):
+		return lbNU
+	}
+	return lbAL
+}
+
+// eastAsianBracket returns true when r is East Asian fullwidth, wide, or
+// halfwidth, which exempts an opening or closing bracket from rule LB30.
+func eastAsianBracket(r rune) bool {
+	return unicode.Is(wideTable, r) || (r >= 0xFF61 && r <= 0xFFDC) || (r >= 0xFFE8 && r <= 0xFFEE)
+}
+
+// lineBreaks returns the byte offsets in s at which a line may be broken,
+// following the rules of the Unicode Line Breaking Algorithm. It never
+// includes 0 or len(s). Escape sequences are ignored when applying the rules,
+// and a break before a rune is placed before any escape sequences that
+// precede it, so they stay with the text that follows.
+func lineBreaks(s string) []int {
+	type item struct {
+		r     rune
+		class lbClass
+		start int // offset of first escape sequence preceding rune, or rune
+		end   int // offset after rune
+	}
+
+	var items []item
+	for i := 0; i < len(s); {
+		start := i
+		for {
+			l := escapeLength(s[i:])
+			if l == 0 {
+				break
+			}
+			i += l
+		}
+		if i == len(s) {
+			break
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		items = append(items, item{r: r, class: lineBreakClass(r), start: start, end: i})
+	}
+
+	var offsets []int
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	prev := items[0].class // class of previous rune after LB9 and LB10
+	if prev == lbCM || prev == lbZWJ {
+		prev = lbAL // LB10
+	}
+	prevRune := items[0].r
+	var prev2 lbClass = lbSP // class of rune before previous rune
+	beforeSP := prev         // class of last rune that is not a space
+	inNumber := prev == lbNU // LB25: within NU (NU|SY|IS)*
+	closeNumber := false     // LB25: within NU (NU|SY|IS)* (CL|CP)
+	var ri int               // number of consecutive regional indicators
+	if prev == lbRI {
+		ri = 1
+	}
+
+	for i := 1; i < len(items); i++ {
+		a, b := prev, items[i].class
+		raw := items[i-1].class // class of previous rune before LB9
+
+		// LB9: Do not break a combining character sequence; treat it as if
+		// it has the class of its base character.
+		if b == lbCM || b == lbZWJ {
+			switch a {
+			case lbBK, lbCR, lbLF, lbNL, lbSP, lbZW:
+				b = lbAL // LB10
+			default:
+				continue
+			}
+		}
+
+		var next lbClass = lbAL
+		if i+1 < len(items) {
+			next = items[i+1].class
+		}
+
+		if lineBreakAllowed(a, b, prev2, beforeSP, raw, next, prevRune, items[i].r, inNumber, closeNumber, ri) {
+			offsets = append(offsets, items[i].start)
+		}
+
+		// Update the state of the rules for the rune just considered.
+		switch {
+		case b == lbNU:
+			inNumber, closeNumber = true, false
+		case inNumber && (b == lbSY || b == lbIS):
+		case inNumber && (b == lbCL || b == lbCP):
+			inNumber, closeNumber = false, true
+		default:
+			inNumber, closeNumber = false, false
+		}
+		if b == lbRI {
+			ri++
+		} else {
+			ri = 0
+		}
+		if b != lbSP {
+			beforeSP = b
+		}
+		prev2, prev, prevRune = a, b, items[i].r
+	}
+
+	return offsets
+}
+
+// lineBreakAllowed returns true when the Unicode Line Breaking Algorithm
+// allows a line break between a rune of class a and a following rune of class
+// b. The remaining arguments hold the context some rules require.
+func lineBreakAllowed(a, b, prev2, beforeSP, raw, next lbClass, ar, br rune, inNumber, closeNumber bool, ri int) bool {
+	switch {
+	// LB4, LB5: Always break after hard line breaks, but not between CR and
+	// LF.
+	case a == lbCR && b == lbLF:
+		return false
+	case a == lbBK || a == lbCR || a == lbLF || a == lbNL:
+		return true
+
+	// LB6: Do not break before hard line breaks.
+	case b == lbBK || b == lbCR || b == lbLF || b == lbNL:
+		return false
+
+	// LB7: Do not break before spaces or zero width space.
+	case b == lbSP || b == lbZW:
+		return false
+
+	// LB8: Break before any character following a zero-width space, even if
+	// one or more spaces intervene.
+	case beforeSP == lbZW:
+		return true
+
+	// LB8a: Do not break after a zero width joiner.
+	case raw == lbZWJ:
+		return false
+
+	// LB11: Do not break before or after word joiner.
+	case a == lbWJ || b == lbWJ:
+		return false
+
+	// LB12, LB12a: Do not break after or before non-breaking glue, except
+	// after spaces and hyphens.
+	case a == lbGL:
+		return false
+	case b == lbGL && a != lbSP && a != lbBA && a != lbHY:
+		return false
+
+	// LB13: Do not break before closing punctuation, exclamation, or
+	// separators.
+	case b == lbCL || b == lbCP || b == lbEX || b == lbIS || b == lbSY:
+		return false
+
+	// LB14: Do not break after opening punctuation, even after spaces.
+	case beforeSP == lbOP:
+		return false
+
+	// LB15: Do not break within quotation followed by opening punctuation,
+	// even with intervening spaces.
+	case beforeSP == lbQU && b == lbOP:
+		return false
+
+	// LB16: Do not break between closing punctuation and a nonstarter, even
+	// with intervening spaces.
+	case (beforeSP == lbCL || beforeSP == lbCP) && b == lbNS:
+		return false
+
+	// LB17: Do not break within B2 pairs, even with intervening spaces.
+	case beforeSP == lbB2 && b == lbB2:
+		return false
+
+	// LB18: Break after spaces.
+	case a == lbSP:
+		return true
+
+	// LB19: Do not break before or after quotation marks.
+	case a == lbQU || b == lbQU:
+		return false
+
+	// LB20: Break before and after contingent break opportunities.
+	case a == lbCB || b == lbCB:
+		return true
+
+	// LB21: Do not break before hyphen-minus, other hyphens, fixed-width
+	// spaces, small kana, and other nonstarters, or after acute accents.
+	case b == lbBA || b == lbHY || b == lbNS || a == lbBB:
+		return false
+
+	// LB21a: Do not break after the hyphen in Hebrew plus hyphen.
+	case prev2 == lbHL && (a == lbHY || a == lbBA):
+		return false
+
+	// LB21b: Do not break between solidus and Hebrew letters.
+	case a == lbSY && b == lbHL:
+		return false
+
+	// LB22: Do not break before ellipses.
+	case b == lbIN:
+		return false
+
+	// LB23: Do not break between digits and letters.
+	case (a == lbAL || a == lbHL) && b == lbNU, a == lbNU && (b == lbAL || b == lbHL):
+		return false
+
+	// LB23a: Do not break between numeric prefixes and ideographs, or
+	// between ideographs and numeric postfixes.
+	case a == lbPR && (b == lbID || b == lbEB || b == lbEM), (a == lbID || a == lbEB || a == lbEM) && b == lbPO:
+		return false
+
+	// LB24: Do not break between numeric prefix or postfix and letters, or
+	// between letters and prefix or postfix.
+	case (a == lbPR || a == lbPO) && (b == lbAL || b == lbHL), (a == lbAL || a == lbHL) && (b == lbPR || b == lbPO):
+		return false
+
+	// LB25: Do not break within numbers, such as "$(12.35)" or "2,1234%".
+	case (a == lbPR || a == lbPO) && b == lbNU,
+		(a == lbPR || a == lbPO) && (b == lbOP || b == lbHY) && next == lbNU,
+		(a == lbOP || a == lbHY) && b == lbNU,
+		inNumber && (b == lbNU || b == lbSY || b == lbIS || b == lbCL || b == lbCP),
+		(inNumber || closeNumber) && (b == lbPO || b == lbPR):
+		return false
+
+	// LB26, LB27: Do not break a Korean syllable, or between a Korean
+	// syllable and a numeric prefix or postfix.
+	case a == lbJL && (b == lbJL || b == lbJV || b == lbH2 || b == lbH3),
+		(a == lbJV || a == lbH2) && (b == lbJV || b == lbJT),
+		(a == lbJT || a == lbH3) && b == lbJT,
+		(a == lbJL || a == lbJV || a == lbJT || a == lbH2 || a == lbH3) && b == lbPO,
+		a == lbPR && (b == lbJL || b == lbJV || b == lbJT || b == lbH2 || b == lbH3):
+		return false
+
+	// LB28: Do not break between alphabetics.
+	case (a == lbAL || a == lbHL) && (b == lbAL || b == lbHL):
+		return false
+
+	// LB29: Do not break between numeric punctuation and alphabetics.
+	case a == lbIS && (b == lbAL || b == lbHL):
+		return false
+
+	// LB30: Do not break between letters, numbers, or ordinary symbols and
+	// opening or closing parentheses that are not East Asian.
+	case (a == lbAL || a == lbHL || a == lbNU) && b == lbOP && !eastAsianBracket(br),
+		a == lbCP && (b == lbAL || b == lbHL || b == lbNU) && !eastAsianBracket(ar):
+		return false
+
+	// LB30a: Break between pairs of regional indicator symbols.
+	case a == lbRI && b == lbRI:
+		return ri%2 == 0
+
+	// LB30b: Do not break between an emoji base, or an unassigned
+	// pictographic code point, and an emoji modifier.
+	case b == lbEM && (a == lbEB || (unicode.Is(extendedPictographic, ar) && !unicode.In(ar, assigned...))):
+		return false
+	}
+
+	// LB31: Break everywhere else.
+	return true
+}
+
+const (
+	nbsp       = '\u00a0' // NO-BREAK SPACE
+	figureSP   = '\u2007' // FIGURE SPACE
+	narrowNBSP = '\u202f' // NARROW NO-BREAK SPACE
+	shy        = "\u00ad" // SOFT HYPHEN
+	zwsp       = "\u200b" // ZERO WIDTH SPACE
+)
+
+// invisible removes soft hyphens and zero width spaces, which only mark
+// optional line break opportunities, from the output.
+var invisible = strings.NewReplacer(shy, "", zwsp, "")
+
+// segment is text between two line break opportunities.
+type segment struct {
+	text  string
+	space bool  // segment ends a word, so is followed by a space
+	shy   []int // byte offsets of soft hyphens at which text may be hyphenated
+}
+
+// isBreakingSpace returns true when r is white space that separates words.
+// The no-break spaces are white space, but glue the words on either side of
+// them together.
+func isBreakingSpace(r rune) bool {
+	return unicode.IsSpace(r) && r != nbsp && r != figureSP && r != narrowNBSP
+}
+
+// segments splits p into the text between its line break opportunities. Runs
+// of white space in p are collapsed to single spaces, which are not included
+// in the text of the segments they end. Soft hyphens and zero width spaces are
+// removed, and the text on either side of a soft hyphen is kept in a single
+// segment that records where it may be hyphenated.
+func segments(p string) []segment {
+	text := strings.Join(strings.FieldsFunc(p, isBreakingSpace), " ")
+	if text == "" {
+		return nil
+	}
+
+	var ss []segment
+	var s segment
+	var last int
+
+	breaks := lineBreaks(text)
+	for _, i := range append(breaks, len(text)) {
+		t := text[last:i]
+		last = i
+
+		var space bool
+		if l := len(t); l > 0 && t[l-1] == ' ' {
+			t, space = t[:l-1], true
+		}
+		hyphen := !space && i < len(text) && strings.HasSuffix(t, shy)
+
+		s.text += invisible.Replace(t)
+		if hyphen {
+			s.shy = append(s.shy, len(s.text))
+			continue
+		}
+		s.space = space
+
+		if s.text != "" {
+			ss = append(ss, s)
+		}
+		s = segment{}
+	}
+
+	return ss
+}
+
+// shift returns the offsets greater than p, less p.
+func shift(offsets []int, p int) []int {
+	var shifted []int
+	for _, o := range offsets {
+		if o > p {
+			shifted = append(shifted, o-p)
+		}
+	}
+	return shifted
+}
+
+// assigned holds the general categories of assigned code points.
+var assigned = []*unicode.RangeTable{unicode.L, unicode.M, unicode.N, unicode.P, unicode.S, unicode.Z, unicode.C}
+
+// lbTable lists the line breaking class of each range of runes that
+// lineBreakClass cannot derive from the general category, taken from
+// https://www.unicode.org/Public/15.0.0/ucd/LineBreak.txt with the classes AI,
+// SG, and XX resolved to AL, SA resolved to CM for combining marks and to AL
+// otherwise, and CJ resolved to NS.
+var lbTable = []lbRange{
+	{0x0000, 0x0008, lbCM},
+	{0x0009, 0x0009, lbBA},
+	{0x000A, 0x000A, lbLF},
+	{0x000B, 0x000C, lbBK},
+	{0x000D, 0x000D, lbCR},
+	{0x000E, 0x001F, lbCM},
+	{0x0020, 0x0020, lbSP},
+	{0x0021, 0x0021, lbEX},
+	{0x0022, 0x0022, lbQU},
+	{0x0024, 0x0024, lbPR},
+	{0x0025, 0x0025, lbPO},
+	{0x0027, 0x0027, lbQU},
+	{0x0028, 0x0028, lbOP},
+	{0x0029, 0x0029, lbCP},
+	{0x002B, 0x002B, lbPR},
+	{0x002C, 0x002C, lbIS},
+	{0x002D, 0x002D, lbHY},
+	{0x002E, 0x002E, lbIS},
+	{0x002F, 0x002F, lbSY},
+	{0x003A, 0x003B, lbIS},
+	{0x003F, 0x003F, lbEX},
+	{0x005B, 0x005B, lbOP},
+	{0x005C, 0x005C, lbPR},
+	{0x005D, 0x005D, lbCP},
+	{0x007B, 0x007B, lbOP},
+	{0x007C, 0x007C, lbBA},
+	{0x007D, 0x007D, lbCL},
+	{0x007F, 0x0084, lbCM},
+	{0x0085, 0x0085, lbNL},
+	{0x0086, 0x009F, lbCM},
+	{0x00A0, 0x00A0, lbGL},
+	{0x00A1, 0x00A1, lbOP},
+	{0x00A2, 0x00A2, lbPO},
+	{0x00A3, 0x00A5, lbPR},
+	{0x00AB, 0x00AB, lbQU},
+	{0x00AD, 0x00AD, lbBA},
+	{0x00B0, 0x00B0, lbPO},
+	{0x00B1, 0x00B1, lbPR},
+	{0x00B4, 0x00B4, lbBB},
+	{0x00BB, 0x00BB, lbQU},
+	{0x00BF, 0x00BF, lbOP},
+	{0x02C8, 0x02C8, lbBB},
+	{0x02CC, 0x02CC, lbBB},
+	{0x02DF, 0x02DF, lbBB},
+	{0x034F, 0x034F, lbGL},
+	{0x035C, 0x0362, lbGL},
+	{0x037E, 0x037E, lbIS},
+	{0x0589, 0x0589, lbIS},
+	{0x058A, 0x058A, lbBA},
+	{0x058F, 0x058F, lbPR},
+	{0x05BE, 0x05BE, lbBA},
+	{0x05C6, 0x05C6, lbEX},
+	{0x05D0, 0x05EA, lbHL},
+	{0x05EF, 0x05F2, lbHL},
+	{0x0609, 0x060B, lbPO},
+	{0x060C, 0x060D, lbIS},
+	{0x061B, 0x061B, lbEX},
+	{0x061C, 0x061C, lbCM},
+	{0x061D, 0x061F, lbEX},
+	{0x066A, 0x066A, lbPO},
+	{0x066B, 0x066C, lbNU},
+	{0x06D4, 0x06D4, lbEX},
+	{0x07F8, 0x07F8, lbIS},
+	{0x07F9, 0x07F9, lbEX},
+	{0x07FE, 0x07FF, lbPR},
+	{0x0964, 0x0965, lbBA},
+	{0x09F2, 0x09F3, lbPO},
+	{0x09F9, 0x09F9, lbPO},
+	{0x09FB, 0x09FB, lbPR},
+	{0x0AF1, 0x0AF1, lbPR},
+	{0x0BF9, 0x0BF9, lbPR},
+	{0x0C77, 0x0C77, lbBB},
+	{0x0C84, 0x0C84, lbBB},
+	{0x0D79, 0x0D79, lbPO},
+	{0x0E3F, 0x0E3F, lbPR},
+	{0x0E5A, 0x0E5B, lbBA},
+	{0x0F01, 0x0F04, lbBB},
+	{0x0F06, 0x0F07, lbBB},
+	{0x0F08, 0x0F08, lbGL},
+	{0x0F09, 0x0F0A, lbBB},
+	{0x0F0B, 0x0F0B, lbBA},
+	{0x0F0C, 0x0F0C, lbGL},
+	{0x0F0D, 0x0F11, lbEX},
+	{0x0F12, 0x0F12, lbGL},
+	{0x0F14, 0x0F14, lbEX},
+	{0x0F34, 0x0F34, lbBA},
+	{0x0F3A, 0x0F3A, lbOP},
+	{0x0F3B, 0x0F3B, lbCL},
+	{0x0F3C, 0x0F3C, lbOP},
+	{0x0F3D, 0x0F3D, lbCL},
+	{0x0F7F, 0x0F7F, lbBA},
+	{0x0F85, 0x0F85, lbBA},
+	{0x0FBE, 0x0FBF, lbBA},
+	{0x0FD0, 0x0FD1, lbBB},
+	{0x0FD2, 0x0FD2, lbBA},
+	{0x0FD3, 0x0FD3, lbBB},
+	{0x0FD9, 0x0FDA, lbGL},
+	{0x104A, 0x104B, lbBA},
+	{0x1100, 0x115F, lbJL},
+	{0x1160, 0x11A7, lbJV},
+	{0x11A8, 0x11FF, lbJT},
+	{0x1361, 0x1361, lbBA},
+	{0x1400, 0x1400, lbBA},
+	{0x1680, 0x1680, lbBA},
+	{0x169B, 0x169B, lbOP},
+	{0x169C, 0x169C, lbCL},
+	{0x16EB, 0x16ED, lbBA},
+	{0x1735, 0x1736, lbBA},
+	{0x17D4, 0x17D5, lbBA},
+	{0x17D6, 0x17D6, lbNS},
+	{0x17D8, 0x17D8, lbBA},
+	{0x17DA, 0x17DA, lbBA},
+	{0x17DB, 0x17DB, lbPR},
+	{0x1802, 0x1803, lbEX},
+	{0x1804, 0x1805, lbBA},
+	{0x1806, 0x1806, lbBB},
+	{0x1808, 0x1809, lbEX},
+	{0x180E, 0x180E, lbGL},
+	{0x1944, 0x1945, lbEX},
+	{0x1B5A, 0x1B5B, lbBA},
+	{0x1B5D, 0x1B60, lbBA},
+	{0x1B7D, 0x1B7E, lbBA},
+	{0x1C3B, 0x1C3F, lbBA},
+	{0x1C7E, 0x1C7F, lbBA},
+	{0x1DCD, 0x1DCD, lbGL},
+	{0x1DFC, 0x1DFC, lbGL},
+	{0x1FFD, 0x1FFD, lbBB},
+	{0x2000, 0x2006, lbBA},
+	{0x2007, 0x2007, lbGL},
+	{0x2008, 0x200A, lbBA},
+	{0x200B, 0x200B, lbZW},
+	{0x200C, 0x200C, lbCM},
+	{0x200D, 0x200D, lbZWJ},
+	{0x200E, 0x200F, lbCM},
+	{0x2010, 0x2010, lbBA},
+	{0x2011, 0x2011, lbGL},
+	{0x2012, 0x2013, lbBA},
+	{0x2014, 0x2014, lbB2},
+	{0x2018, 0x2019, lbQU},
+	{0x201A, 0x201A, lbOP},
+	{0x201B, 0x201D, lbQU},
+	{0x201E, 0x201E, lbOP},
+	{0x201F, 0x201F, lbQU},
+	{0x2024, 0x2026, lbIN},
+	{0x2027, 0x2027, lbBA},
+	{0x2028, 0x2029, lbBK},
+	{0x202A, 0x202E, lbCM},
+	{0x202F, 0x202F, lbGL},
+	{0x2030, 0x2037, lbPO},
+	{0x2039, 0x203A, lbQU},
+	{0x203C, 0x203D, lbNS},
+	{0x2044, 0x2044, lbIS},
+	{0x2045, 0x2045, lbOP},
+	{0x2046, 0x2046, lbCL},
+	{0x2047, 0x2049, lbNS},
+	{0x2056, 0x2056, lbBA},
+	{0x2057, 0x2057, lbPO},
+	{0x2058, 0x205B, lbBA},
+	{0x205D, 0x205F, lbBA},
+	{0x2060, 0x2060, lbWJ},
+	{0x2066, 0x206F, lbCM},
+	{0x207D, 0x207D, lbOP},
+	{0x207E, 0x207E, lbCL},
+	{0x208D, 0x208D, lbOP},
+	{0x208E, 0x208E, lbCL},
+	{0x20A0, 0x20A6, lbPR},
+	{0x20A7, 0x20A7, lbPO},
+	{0x20A8, 0x20B5, lbPR},
+	{0x20B6, 0x20B6, lbPO},
+	{0x20B7, 0x20BA, lbPR},
+	{0x20BB, 0x20BB, lbPO},
+	{0x20BC, 0x20BD, lbPR},
+	{0x20BE, 0x20BE, lbPO},
+	{0x20BF, 0x20BF, lbPR},
+	{0x20C0, 0x20C0, lbPO},
+	{0x20C1, 0x20CF, lbPR},
+	{0x2103, 0x2103, lbPO},
+	{0x2109, 0x2109, lbPO},
+	{0x2116, 0x2116, lbPR},
+	{0x2212, 0x2213, lbPR},
+	{0x22EF, 0x22EF, lbIN},
+	{0x2308, 0x2308, lbOP},
+	{0x2309, 0x2309, lbCL},
+	{0x230A, 0x230A, lbOP},
+	{0x230B, 0x230B, lbCL},
+	{0x231A, 0x231B, lbID},
+	{0x2329, 0x2329, lbOP},
+	{0x232A, 0x232A, lbCL},
+	{0x23F0, 0x23F3, lbID},
+	{0x2600, 0x2603, lbID},
+	{0x2614, 0x2615, lbID},
+	{0x2618, 0x2618, lbID},
+	{0x261A, 0x261C, lbID},
+	{0x261D, 0x261D, lbEB},
+	{0x261E, 0x261F, lbID},
+	{0x2639, 0x263B, lbID},
+	{0x2668, 0x2668, lbID},
+	{0x267F, 0x267F, lbID},
+	{0x26BD, 0x26C8, lbID},
+	{0x26CD, 0x26CD, lbID},
+	{0x26CF, 0x26D1, lbID},
+	{0x26D3, 0x26D4, lbID},
+	{0x26D8, 0x26D9, lbID},
+	{0x26DC, 0x26DC, lbID},
+	{0x26DF, 0x26E1, lbID},
+	{0x26EA, 0x26EA, lbID},
+	{0x26F1, 0x26F5, lbID},
+	{0x26F7, 0x26F8, lbID},
+	{0x26F9, 0x26F9, lbEB},
+	{0x26FA, 0x26FA, lbID},
+	{0x26FD, 0x2704, lbID},
+	{0x2708, 0x2709, lbID},
+	{0x270A, 0x270D, lbEB},
+	{0x275B, 0x2760, lbQU},
+	{0x2762, 0x2763, lbEX},
+	{0x2764, 0x2764, lbID},
+	{0x2768, 0x2768, lbOP},
+	{0x2769, 0x2769, lbCL},
+	{0x276A, 0x276A, lbOP},
+	{0x276B, 0x276B, lbCL},
+	{0x276C, 0x276C, lbOP},
+	{0x276D, 0x276D, lbCL},
+	{0x276E, 0x276E, lbOP},
+	{0x276F, 0x276F, lbCL},
+	{0x2770, 0x2770, lbOP},
+	{0x2771, 0x2771, lbCL},
+	{0x2772, 0x2772, lbOP},
+	{0x2773, 0x2773, lbCL},
+	{0x2774, 0x2774, lbOP},
+	{0x2775, 0x2775, lbCL},
+	{0x27C5, 0x27C5, lbOP},
+	{0x27C6, 0x27C6, lbCL},
+	{0x27E6, 0x27E6, lbOP},
+	{0x27E7, 0x27E7, lbCL},
+	{0x27E8, 0x27E8, lbOP},
+	{0x27E9, 0x27E9, lbCL},
+	{0x27EA, 0x27EA, lbOP},
+	{0x27EB, 0x27EB, lbCL},
+	{0x27EC, 0x27EC, lbOP},
+	{0x27ED, 0x27ED, lbCL},
+	{0x27EE, 0x27EE, lbOP},
+	{0x27EF, 0x27EF, lbCL},
+	{0x2983, 0x2983, lbOP},
+	{0x2984, 0x2984, lbCL},
+	{0x2985, 0x2985, lbOP},
+	{0x2986, 0x2986, lbCL},
+	{0x2987, 0x2987, lbOP},
+	{0x2988, 0x2988, lbCL},
+	{0x2989, 0x2989, lbOP},
+	{0x298A, 0x298A, lbCL},
+	{0x298B, 0x298B, lbOP},
+	{0x298C, 0x298C, lbCL},
+	{0x298D, 0x298D, lbOP},
+	{0x298E, 0x298E, lbCL},
+	{0x298F, 0x298F, lbOP},
+	{0x2990, 0x2990, lbCL},
+	{0x2991, 0x2991, lbOP},
+	{0x2992, 0x2992, lbCL},
+	{0x2993, 0x2993, lbOP},
+	{0x2994, 0x2994, lbCL},
+	{0x2995, 0x2995, lbOP},
+	{0x2996, 0x2996, lbCL},
+	{0x2997, 0x2997, lbOP},
+	{0x2998, 0x2998, lbCL},
+	{0x29D8, 0x29D8, lbOP},
+	{0x29D9, 0x29D9, lbCL},
+	{0x29DA, 0x29DA, lbOP},
+	{0x29DB, 0x29DB, lbCL},
+	{0x29FC, 0x29FC, lbOP},
+	{0x29FD, 0x29FD, lbCL},
+	{0x2CF9, 0x2CF9, lbEX},
+	{0x2CFA, 0x2CFC, lbBA},
+	{0x2CFE, 0x2CFE, lbEX},
+	{0x2CFF, 0x2CFF, lbBA},
+	{0x2D70, 0x2D70, lbBA},
+	{0x2E00, 0x2E0D, lbQU},
+	{0x2E0E, 0x2E15, lbBA},
+	{0x2E17, 0x2E17, lbBA},
+	{0x2E18, 0x2E18, lbOP},
+	{0x2E19, 0x2E19, lbBA},
+	{0x2E1C, 0x2E1D, lbQU},
+	{0x2E20, 0x2E21, lbQU},
+	{0x2E22, 0x2E22, lbOP},
+	{0x2E23, 0x2E23, lbCL},
+	{0x2E24, 0x2E24, lbOP},
+	{0x2E25, 0x2E25, lbCL},
+	{0x2E26, 0x2E26, lbOP},
+	{0x2E27, 0x2E27, lbCL},
+	{0x2E28, 0x2E28, lbOP},
+	{0x2E29, 0x2E29, lbCL},
+	{0x2E2A, 0x2E2D, lbBA},
+	{0x2E2E, 0x2E2E, lbEX},
+	{0x2E30, 0x2E31, lbBA},
+	{0x2E33, 0x2E34, lbBA},
+	{0x2E3A, 0x2E3B, lbB2},
+	{0x2E3C, 0x2E3E, lbBA},
+	{0x2E40, 0x2E41, lbBA},
+	{0x2E42, 0x2E42, lbOP},
+	{0x2E43, 0x2E4A, lbBA},
+	{0x2E4C, 0x2E4C, lbBA},
+	{0x2E4E, 0x2E4F, lbBA},
+	{0x2E53, 0x2E54, lbEX},
+	{0x2E55, 0x2E55, lbOP},
+	{0x2E56, 0x2E56, lbCL},
+	{0x2E57, 0x2E57, lbOP},
+	{0x2E58, 0x2E58, lbCL},
+	{0x2E59, 0x2E59, lbOP},
+	{0x2E5A, 0x2E5A, lbCL},
+	{0x2E5B, 0x2E5B, lbOP},
+	{0x2E5C, 0x2E5C, lbCL},
+	{0x2E5D, 0x2E5D, lbBA},
+	{0x2E80, 0x2E99, lbID},
+	{0x2E9B, 0x2EF3, lbID},
+	{0x2F00, 0x2FD5, lbID},
+	{0x2FF0, 0x2FFB, lbID},
+	{0x3000, 0x3000, lbBA},
+	{0x3001, 0x3002, lbCL},
+	{0x3003, 0x3004, lbID},
+	{0x3005, 0x3005, lbNS},
+	{0x3006, 0x3007, lbID},
+	{0x3008, 0x3008, lbOP},
+	{0x3009, 0x3009, lbCL},
+	{0x300A, 0x300A, lbOP},
+	{0x300B, 0x300B, lbCL},
+	{0x300C, 0x300C, lbOP},
+	{0x300D, 0x300D, lbCL},
+	{0x300E, 0x300E, lbOP},
+	{0x300F, 0x300F, lbCL},
+	{0x3010, 0x3010, lbOP},
+	{0x3011, 0x3011, lbCL},
+	{0x3012, 0x3013, lbID},
+	{0x3014, 0x3014, lbOP},
+	{0x3015, 0x3015, lbCL},
+	{0x3016, 0x3016, lbOP},
+	{0x3017, 0x3017, lbCL},
+	{0x3018, 0x3018, lbOP},
+	{0x3019, 0x3019, lbCL},
+	{0x301A, 0x301A, lbOP},
+	{0x301B, 0x301B, lbCL},
+	{0x301C, 0x301C, lbNS},
+	{0x301D, 0x301D, lbOP},
+	{0x301E, 0x301F, lbCL},
+	{0x3020, 0x3029, lbID},
+	{0x3030, 0x3034, lbID},
+	{0x3035, 0x3035, lbCM},
+	{0x3036, 0x303A, lbID},
+	{0x303B, 0x303C, lbNS},
+	{0x303D, 0x303F, lbID},
+	{0x3041, 0x3041, lbNS},
+	{0x3042, 0x3042, lbID},
+	{0x3043, 0x3043, lbNS},
+	{0x3044, 0x3044, lbID},
+	{0x3045, 0x3045, lbNS},
+	{0x3046, 0x3046, lbID},
+	{0x3047, 0x3047, lbNS},
+	{0x3048, 0x3048, lbID},
+	{0x3049, 0x3049, lbNS},
+	{0x304A, 0x3062, lbID},
+	{0x3063, 0x3063, lbNS},
+	{0x3064, 0x3082, lbID},
+	{0x3083, 0x3083, lbNS},
+	{0x3084, 0x3084, lbID},
+	{0x3085, 0x3085, lbNS},
+	{0x3086, 0x3086, lbID},
+	{0x3087, 0x3087, lbNS},
+	{0x3088, 0x308D, lbID},
+	{0x308E, 0x308E, lbNS},
+	{0x308F, 0x3094, lbID},
+	{0x3095, 0x3096, lbNS},
+	{0x309B, 0x309E, lbNS},
+	{0x309F, 0x309F, lbID},
+	{0x30A0, 0x30A1, lbNS},
+	{0x30A2, 0x30A2, lbID},
+	{0x30A3, 0x30A3, lbNS},
+	{0x30A4, 0x30A4, lbID},
+	{0x30A5, 0x30A5, lbNS},
+	{0x30A6, 0x30A6, lbID},
+	{0x30A7, 0x30A7, lbNS},
+	{0x30A8, 0x30A8, lbID},
+	{0x30A9, 0x30A9, lbNS},
+	{0x30AA, 0x30C2, lbID},
+	{0x30C3, 0x30C3, lbNS},
+	{0x30C4, 0x30E2, lbID},
+	{0x30E3, 0x30E3, lbNS},
+	{0x30E4, 0x30E4, lbID},
+	{0x30E5, 0x30E5, lbNS},
+	{0x30E6, 0x30E6, lbID},
+	{0x30E7, 0x30E7, lbNS},
+	{0x30E8, 0x30ED, lbID},
+	{0x30EE, 0x30EE, lbNS},
+	{0x30EF, 0x30F4, lbID},
+	{0x30F5, 0x30F6, lbNS},
+	{0x30F7, 0x30FA, lbID},
+	{0x30FB, 0x30FE, lbNS},
+	{0x30FF, 0x30FF, lbID},
+	{0x3105, 0x312F, lbID},
+	{0x3131, 0x318E, lbID},
+	{0x3190, 0x31E3, lbID},
+	{0x31F0, 0x31FF, lbNS},
+	{0x3200, 0x321E, lbID},
+	{0x3220, 0x3247, lbID},
+	{0x3250, 0x4DBF, lbID},
+	{0x4E00, 0xA014, lbID},
+	{0xA015, 0xA015, lbNS},
+	{0xA016, 0xA48C, lbID},
+	{0xA490, 0xA4C6, lbID},
+	{0xA4FE, 0xA4FF, lbBA},
+	{0xA60D, 0xA60D, lbBA},
+	{0xA60E, 0xA60E, lbEX},
+	{0xA60F, 0xA60F, lbBA},
+	{0xA6F3, 0xA6F7, lbBA},
+	{0xA838, 0xA838, lbPO},
+	{0xA874, 0xA875, lbBB},
+	{0xA876, 0xA877, lbEX},
+	{0xA8CE, 0xA8CF, lbBA},
+	{0xA8FC, 0xA8FC, lbBB},
+	{0xA92E, 0xA92F, lbBA},
+	{0xA960, 0xA97C, lbJL},
+	{0xA9C7, 0xA9C9, lbBA},
+	{0xAA5D, 0xAA5F, lbBA},
+	{0xAAF0, 0xAAF1, lbBA},
+	{0xABEB, 0xABEB, lbBA},
+	{0xD7B0, 0xD7C6, lbJV},
+	{0xD7CB, 0xD7FB, lbJT},
+	{0xF900, 0xFAFF, lbID},
+	{0xFB1D, 0xFB1D, lbHL},
+	{0xFB1F, 0xFB28, lbHL},
+	{0xFB2A, 0xFB36, lbHL},
+	{0xFB38, 0xFB3C, lbHL},
+	{0xFB3E, 0xFB3E, lbHL},
+	{0xFB40, 0xFB41, lbHL},
+	{0xFB43, 0xFB44, lbHL},
+	{0xFB46, 0xFB4F, lbHL},
+	{0xFD3E, 0xFD3E, lbCL},
+	{0xFD3F, 0xFD3F, lbOP},
+	{0xFDFC, 0xFDFC, lbPO},
+	{0xFE10, 0xFE10, lbIS},
+	{0xFE11, 0xFE12, lbCL},
+	{0xFE13, 0xFE14, lbIS},
+	{0xFE15, 0xFE16, lbEX},
+	{0xFE17, 0xFE17, lbOP},
+	{0xFE18, 0xFE18, lbCL},
+	{0xFE19, 0xFE19, lbIN},
+	{0xFE30, 0xFE34, lbID},
+	{0xFE35, 0xFE35, lbOP},
+	{0xFE36, 0xFE36, lbCL},
+	{0xFE37, 0xFE37, lbOP},
+	{0xFE38, 0xFE38, lbCL},
+	{0xFE39, 0xFE39, lbOP},
+	{0xFE3A, 0xFE3A, lbCL},
+	{0xFE3B, 0xFE3B, lbOP},
+	{0xFE3C, 0xFE3C, lbCL},
+	{0xFE3D, 0xFE3D, lbOP},
+	{0xFE3E, 0xFE3E, lbCL},
+	{0xFE3F, 0xFE3F, lbOP},
+	{0xFE40, 0xFE40, lbCL},
+	{0xFE41, 0xFE41, lbOP},
+	{0xFE42, 0xFE42, lbCL},
+	{0xFE43, 0xFE43, lbOP},
+	{0xFE44, 0xFE44, lbCL},
+	{0xFE45, 0xFE46, lbID},
+	{0xFE47, 0xFE47, lbOP},
+	{0xFE48, 0xFE48, lbCL},
+	{0xFE49, 0xFE4F, lbID},
+	{0xFE50, 0xFE50, lbCL},
+	{0xFE51, 0xFE51, lbID},
+	{0xFE52, 0xFE52, lbCL},
+	{0xFE54, 0xFE55, lbNS},
+	{0xFE56, 0xFE57, lbEX},
+	{0xFE58, 0xFE58, lbID},
+	{0xFE59, 0xFE59, lbOP},
+	{0xFE5A, 0xFE5A, lbCL},
+	{0xFE5B, 0xFE5B, lbOP},
+	{0xFE5C, 0xFE5C, lbCL},
+	{0xFE5D, 0xFE5D, lbOP},
+	{0xFE5E, 0xFE5E, lbCL},
+	{0xFE5F, 0xFE66, lbID},
+	{0xFE68, 0xFE68, lbID},
+	{0xFE69, 0xFE69, lbPR},
+	{0xFE6A, 0xFE6A, lbPO},
+	{0xFE6B, 0xFE6B, lbID},
+	{0xFEFF, 0xFEFF, lbWJ},
+	{0xFF01, 0xFF01, lbEX},
+	{0xFF02, 0xFF03, lbID},
+	{0xFF04, 0xFF04, lbPR},
+	{0xFF05, 0xFF05, lbPO},
+	{0xFF06, 0xFF07, lbID},
+	{0xFF08, 0xFF08, lbOP},
+	{0xFF09, 0xFF09, lbCL},
+	{0xFF0A, 0xFF0B, lbID},
+	{0xFF0C, 0xFF0C, lbCL},
+	{0xFF0D, 0xFF0D, lbID},
+	{0xFF0E, 0xFF0E, lbCL},
+	{0xFF0F, 0xFF19, lbID},
+	{0xFF1A, 0xFF1B, lbNS},
+	{0xFF1C, 0xFF1E, lbID},
+	{0xFF1F, 0xFF1F, lbEX},
+	{0xFF20, 0xFF3A, lbID},
+	{0xFF3B, 0xFF3B, lbOP},
+	{0xFF3C, 0xFF3C, lbID},
+	{0xFF3D, 0xFF3D, lbCL},
+	{0xFF3E, 0xFF5A, lbID},
+	{0xFF5B, 0xFF5B, lbOP},
+	{0xFF5C, 0xFF5C, lbID},
+	{0xFF5D, 0xFF5D, lbCL},
+	{0xFF5E, 0xFF5E, lbID},
+	{0xFF5F, 0xFF5F, lbOP},
+	{0xFF60, 0xFF61, lbCL},
+	{0xFF62, 0xFF62, lbOP},
+	{0xFF63, 0xFF64, lbCL},
+	{0xFF65, 0xFF65, lbNS},
+	{0xFF66, 0xFF66, lbID},
+	{0xFF67, 0xFF70, lbNS},
+	{0xFF71, 0xFF9D, lbID},
+	{0xFF9E, 0xFF9F, lbNS},
+	{0xFFA0, 0xFFBE, lbID},
+	{0xFFC2, 0xFFC7, lbID},
+	{0xFFCA, 0xFFCF, lbID},
+	{0xFFD2, 0xFFD7, lbID},
+	{0xFFDA, 0xFFDC, lbID},
+	{0xFFE0, 0xFFE0, lbPO},
+	{0xFFE1, 0xFFE1, lbPR},
+	{0xFFE2, 0xFFE4, lbID},
+	{0xFFE5, 0xFFE6, lbPR},
+	{0xFFF9, 0xFFFB, lbCM},
+	{0xFFFC, 0xFFFC, lbCB},
+	{0x10100, 0x10102, lbBA},
+	{0x1039F, 0x1039F, lbBA},
+	{0x103D0, 0x103D0, lbBA},
+	{0x10857, 0x10857, lbBA},
+	{0x1091F, 0x1091F, lbBA},
+	{0x10A50, 0x10A57, lbBA},
+	{0x10AF0, 0x10AF5, lbBA},
+	{0x10AF6, 0x10AF6, lbIN},
+	{0x10B39, 0x10B3F, lbBA},
+	{0x10EAD, 0x10EAD, lbBA},
+	{0x11047, 0x11048, lbBA},
+	{0x110BE, 0x110C1, lbBA},
+	{0x11140, 0x11143, lbBA},
+	{0x11175, 0x11175, lbBB},
+	{0x111C5, 0x111C6, lbBA},
+	{0x111C8, 0x111C8, lbBA},
+	{0x111DB, 0x111DB, lbBB},
+	{0x111DD, 0x111DF, lbBA},
+	{0x11238, 0x11239, lbBA},
+	{0x1123B, 0x1123C, lbBA},
+	{0x112A9, 0x112A9, lbBA},
+	{0x1144B, 0x1144E, lbBA},
+	{0x1145A, 0x1145B, lbBA},
+	{0x115C1, 0x115C1, lbBB},
+	{0x115C2, 0x115C3, lbBA},
+	{0x115C4, 0x115C5, lbEX},
+	{0x115C9, 0x115D7, lbBA},
+	{0x11641, 0x11642, lbBA},
+	{0x11660, 0x1166C, lbBB},
+	{0x1173C, 0x1173E, lbBA},
+	{0x11944, 0x11946, lbBA},
+	{0x119E2, 0x119E2, lbBB},
+	{0x11A3F, 0x11A3F, lbBB},
+	{0x11A41, 0x11A44, lbBA},
+	{0x11A45, 0x11A45, lbBB},
+	{0x11A9A, 0x11A9C, lbBA},
+	{0x11A9E, 0x11AA0, lbBB},
+	{0x11AA1, 0x11AA2, lbBA},
+	{0x11B00, 0x11B09, lbBB},
+	{0x11C41, 0x11C45, lbBA},
+	{0x11C70, 0x11C70, lbBB},
+	{0x11C71, 0x11C71, lbEX},
+	{0x11F43, 0x11F44, lbBA},
+	{0x11F45, 0x11F4F, lbID},
+	{0x11FDD, 0x11FE0, lbPO},
+	{0x11FFF, 0x11FFF, lbBA},
+	{0x12470, 0x12474, lbBA},
+	{0x13258, 0x1325A, lbOP},
+	{0x1325B, 0x1325D, lbCL},
+	{0x13282, 0x13282, lbCL},
+	{0x13286, 0x13286, lbOP},
+	{0x13287, 0x13287, lbCL},
+	{0x13288, 0x13288, lbOP},
+	{0x13289, 0x13289, lbCL},
+	{0x13379, 0x13379, lbOP},
+	{0x1337A, 0x1337B, lbCL},
+	{0x13430, 0x13436, lbGL},
+	{0x13437, 0x13437, lbOP},
+	{0x13438, 0x13438, lbCL},
+	{0x13439, 0x1343B, lbGL},
+	{0x1343C, 0x1343C, lbOP},
+	{0x1343D, 0x1343D, lbCL},
+	{0x1343E, 0x1343E, lbOP},
+	{0x1343F, 0x1343F, lbCL},
+	{0x145CE, 0x145CE, lbOP},
+	{0x145CF, 0x145CF, lbCL},
+	{0x16A6E, 0x16A6F, lbBA},
+	{0x16AF5, 0x16AF5, lbBA},
+	{0x16B37, 0x16B39, lbBA},
+	{0x16B44, 0x16B44, lbBA},
+	{0x16E97, 0x16E98, lbBA},
+	{0x16FE0, 0x16FE3, lbNS},
+	{0x16FE4, 0x16FE4, lbGL},
+	{0x17000, 0x187F7, lbID},
+	{0x18800, 0x18AFF, lbID},
+	{0x18D00, 0x18D08, lbID},
+	{0x1B000, 0x1B122, lbID},
+	{0x1B132, 0x1B132, lbNS},
+	{0x1B150, 0x1B152, lbNS},
+	{0x1B155, 0x1B155, lbNS},
+	{0x1B164, 0x1B167, lbNS},
+	{0x1B170, 0x1B2FB, lbID},
+	{0x1BC9F, 0x1BC9F, lbBA},
+	{0x1BCA0, 0x1BCA3, lbCM},
+	{0x1D173, 0x1D17A, lbCM},
+	{0x1DA87, 0x1DA8A, lbBA},
+	{0x1E2FF, 0x1E2FF, lbPR},
+	{0x1E95E, 0x1E95F, lbOP},
+	{0x1ECAC, 0x1ECAC, lbPO},
+	{0x1ECB0, 0x1ECB0, lbPO},
+	{0x1F000, 0x1F0FF, lbID},
+	{0x1F10D, 0x1F10F, lbID},
+	{0x1F16D, 0x1F16F, lbID},
+	{0x1F1AD, 0x1F1E5, lbID},
+	{0x1F1E6, 0x1F1FF, lbRI},
+	{0x1F200, 0x1F384, lbID},
+	{0x1F385, 0x1F385, lbEB},
+	{0x1F386, 0x1F39B, lbID},
+	{0x1F39E, 0x1F3B4, lbID},
+	{0x1F3B7, 0x1F3BB, lbID},
+	{0x1F3BD, 0x1F3C1, lbID},
+	{0x1F3C2, 0x1F3C4, lbEB},
+	{0x1F3C5, 0x1F3C6, lbID},
+	{0x1F3C7, 0x1F3C7, lbEB},
+	{0x1F3C8, 0x1F3C9, lbID},
+	{0x1F3CA, 0x1F3CC, lbEB},
+	{0x1F3CD, 0x1F3FA, lbID},
+	{0x1F3FB, 0x1F3FF, lbEM},
+	{0x1F400, 0x1F441, lbID},
+	{0x1F442, 0x1F443, lbEB},
+	{0x1F444, 0x1F445, lbID},
+	{0x1F446, 0x1F450, lbEB},
+	{0x1F451, 0x1F465, lbID},
+	{0x1F466, 0x1F478, lbEB},
+	{0x1F479, 0x1F47B, lbID},
+	{0x1F47C, 0x1F47C, lbEB},
+	{0x1F47D, 0x1F480, lbID},
+	{0x1F481, 0x1F483, lbEB},
+	{0x1F484, 0x1F484, lbID},
+	{0x1F485, 0x1F487, lbEB},
+	{0x1F488, 0x1F48E, lbID},
+	{0x1F48F, 0x1F48F, lbEB},
+	{0x1F490, 0x1F490, lbID},
+	{0x1F491, 0x1F491, lbEB},
+	{0x1F492, 0x1F49F, lbID},
+	{0x1F4A1, 0x1F4A1, lbID},
+	{0x1F4A3, 0x1F4A3, lbID},
+	{0x1F4A5, 0x1F4A9, lbID},
+	{0x1F4AA, 0x1F4AA, lbEB},
+	{0x1F4AB, 0x1F4AE, lbID},
+	{0x1F4B0, 0x1F4B0, lbID},
+	{0x1F4B3, 0x1F4FF, lbID},
+	{0x1F507, 0x1F516, lbID},
+	{0x1F525, 0x1F531, lbID},
+	{0x1F54A, 0x1F573, lbID},
+	{0x1F574, 0x1F575, lbEB},
+	{0x1F576, 0x1F579, lbID},
+	{0x1F57A, 0x1F57A, lbEB},
+	{0x1F57B, 0x1F58F, lbID},
+	{0x1F590, 0x1F590, lbEB},
+	{0x1F591, 0x1F594, lbID},
+	{0x1F595, 0x1F596, lbEB},
+	{0x1F597, 0x1F5D3, lbID},
+	{0x1F5DC, 0x1F5F3, lbID},
+	{0x1F5FA, 0x1F644, lbID},
+	{0x1F645, 0x1F647, lbEB},
+	{0x1F648, 0x1F64A, lbID},
+	{0x1F64B, 0x1F64F, lbEB},
+	{0x1F676, 0x1F678, lbQU},
+	{0x1F679, 0x1F67B, lbNS},
+	{0x1F680, 0x1F6A2, lbID},
+	{0x1F6A3, 0x1F6A3, lbEB},
+	{0x1F6A4, 0x1F6B3, lbID},
+	{0x1F6B4, 0x1F6B6, lbEB},
+	{0x1F6B7, 0x1F6BF, lbID},
+	{0x1F6C0, 0x1F6C0, lbEB},
+	{0x1F6C1, 0x1F6CB, lbID},
+	{0x1F6CC, 0x1F6CC, lbEB},
+	{0x1F6CD, 0x1F6FF, lbID},
+	{0x1F774, 0x1F77F, lbID},
+	{0x1F7D5, 0x1F7FF, lbID},
+	{0x1F80C, 0x1F80F, lbID},
+	{0x1F848, 0x1F84F, lbID},
+	{0x1F85A, 0x1F85F, lbID},
+	{0x1F888, 0x1F88F, lbID},
+	{0x1F8AE, 0x1F8FF, lbID},
+	{0x1F90C, 0x1F90C, lbEB},
+	{0x1F90D, 0x1F90E, lbID},
+	{0x1F90F, 0x1F90F, lbEB},
+	{0x1F910, 0x1F917, lbID},
+	{0x1F918, 0x1F91F, lbEB},
+	{0x1F920, 0x1F925, lbID},
+	{0x1F926, 0x1F926, lbEB},
+	{0x1F927, 0x1F92F, lbID},
+	{0x1F930, 0x1F939, lbEB},
+	{0x1F93A, 0x1F93B, lbID},
+	{0x1F93C, 0x1F93E, lbEB},
+	{0x1F93F, 0x1F976, lbID},
+	{0x1F977, 0x1F977, lbEB},
+	{0x1F978, 0x1F9B4, lbID},
+	{0x1F9B5, 0x1F9B6, lbEB},
+	{0x1F9B7, 0x1F9B7, lbID},
+	{0x1F9B8, 0x1F9B9, lbEB},
+	{0x1F9BA, 0x1F9BA, lbID},
+	{0x1F9BB, 0x1F9BB, lbEB},
+	{0x1F9BC, 0x1F9CC, lbID},
+	{0x1F9CD, 0x1F9CF, lbEB},
+	{0x1F9D0, 0x1F9D0, lbID},
+	{0x1F9D1, 0x1F9DD, lbEB},
+	{0x1F9DE, 0x1F9FF, lbID},
+	{0x1FA54, 0x1FAC2, lbID},
+	{0x1FAC3, 0x1FAC5, lbEB},
+	{0x1FAC6, 0x1FAEF, lbID},
+	{0x1FAF0, 0x1FAF8, lbEB},
+	{0x1FAF9, 0x1FAFF, lbID},
+	{0x1FC00, 0x1FFFD, lbID},
+	{0x20000, 0x2FFFD, lbID},
+	{0x30000, 0x3FFFD, lbID},
+	{0xE0001, 0xE0001, lbCM},
+	{0xE0020, 0xE007F, lbCM},
+}
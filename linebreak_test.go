@@ -0,0 +1,116 @@
+package golinewrap_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/karrick/golinewrap"
+)
+
+func TestLineBreakOpportunities(t *testing.T) {
+	emit := func(t *testing.T, width int, prefix string, p string) string {
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, width, prefix)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = lw.WriteParagraph(p); err != nil {
+			t.Fatal(err)
+		}
+
+		return string(bb.Bytes())
+	}
+
+	t.Run("after em dash", func(t *testing.T) {
+		got := emit(t, 12, ">", "wrap—dashes")
+		if want := ">wrap—\n>dashes\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("after hyphen", func(t *testing.T) {
+		got := emit(t, 12, ">", "a well-known fact")
+		if want := ">a well-\n>known fact\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("after slash", func(t *testing.T) {
+		got := emit(t, 12, ">", "either/or/neither")
+		if want := ">either/or/\n>neither\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("not within number", func(t *testing.T) {
+		got := emit(t, 12, ">", "pay $(12.35) now")
+		if want := ">pay\n>$(12.35)\n>now\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("between ideographs", func(t *testing.T) {
+		got := emit(t, 10, ">", "中文文本没有空格")
+		if want := ">中文文本\n>没有空格\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("not before closing punctuation", func(t *testing.T) {
+		got := emit(t, 10, ">", "中文文本。没有")
+		if want := ">中文文\n>本。没有\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("not before nonstarter", func(t *testing.T) {
+		got := emit(t, 10, ">", "ちょっとまって")
+		if want := ">ちょっと\n>まって\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("not at no-break space", func(t *testing.T) {
+		got := emit(t, 10, ">", "run 10\u00a0km today")
+		if want := ">run\n>10\u00a0km\n>today\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("not at narrow or figure space", func(t *testing.T) {
+		got := emit(t, 10, ">", "a 1\u2007000\u202f\u20ac")
+		if want := ">a\n>1\u2007000\u202f\u20ac\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("at soft hyphen", func(t *testing.T) {
+		got := emit(t, 12, ">", "big hy\u00adphen\u00adation")
+		if want := ">big hy-\n>phenation\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("soft hyphen invisible when not used", func(t *testing.T) {
+		got := emit(t, 20, ">", "big hy\u00adphen\u00adation")
+		if want := ">big hyphenation\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("at zero width space", func(t *testing.T) {
+		got := emit(t, 8, ">", "alpha\u200bbeta")
+		if want := ">alpha\n>beta\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("zero width space invisible when not used", func(t *testing.T) {
+		got := emit(t, 20, ">", "alpha\u200bbeta")
+		if want := ">alphabeta\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+}
@@ -0,0 +1,159 @@
+package golinewrap
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrWordTooLong is returned when the Writer is configured with LongWordError
+// and asked to write a word that is wider than an entire line.
+var ErrWordTooLong = errors.New("word is wider than line")
+
+// LongWordPolicy determines what the Writer does with a word that is wider
+// than an entire line.
+type LongWordPolicy int
+
+const (
+	// LongWordOverflow emits a long word on a line of its own, allowing it to
+	// overflow the width. This is the default.
+	LongWordOverflow LongWordPolicy = iota
+
+	// LongWordBreak breaks a long word at the width, continuing it on the
+	// following lines.
+	LongWordBreak
+
+	// LongWordBreakWithMark breaks a long word at the width like
+	// LongWordBreak, but ends each broken line with the continuation mark,
+	// which is a hyphen unless changed by SetContinuationMark.
+	LongWordBreakWithMark
+
+	// LongWordError causes a long word to be rejected with an error that
+	// wraps ErrWordTooLong.
+	LongWordError
+)
+
+// SetLongWordPolicy changes what the Writer does with a word that is wider
+// than an entire line.
+func (ww *Writer) SetLongWordPolicy(p LongWordPolicy) error {
+	if p < LongWordOverflow || p > LongWordError {
+		return fmt.Errorf("cannot set unknown long word policy: %d", p)
+	}
+	ww.longWord = p
+	return nil
+}
+
+// SetContinuationMark changes the string appended to each line of a broken
+// long word when the long word policy is LongWordBreakWithMark. It returns an
+// error unless the mark is narrower than the space available on a line.
+func (ww *Writer) SetContinuationMark(mark string) error {
+	if c := ww.columns(mark); c >= ww.capacity() {
+		return fmt.Errorf("cannot set continuation mark unless number of columns it uses (%d) is less than number of columns available on a line: %d.", c, ww.capacity())
+	}
+	ww.mark = mark
+	return nil
+}
+
+// capacity returns the number of columns available for text on a line after
+// the continuation prefix and indentation and before the suffix, reserving a
+// column for the newline character. When a PrefixFunc builds each prefix, the
+// prefix of the current line is used instead.
+func (ww *Writer) capacity() int {
+	if ww.prefixFunc != nil {
+		return ww.lineCapacity()
+	}
+	return ww.max - ww.prefixColumns - ww.indentColumns - ww.suffixColumns - 1
+}
+
+// lineCapacity returns the number of columns available for text on the
+// current line after its prefix and before the suffix, reserving a column for
+// the newline character.
+func (ww *Writer) lineCapacity() int {
+	return ww.max - ww.lineColumns - ww.suffixColumns - 1
+}
+
+// lineEmpty returns true when no text has been written on the current line.
+func (ww *Writer) lineEmpty() bool {
+	return ww.remaining == ww.lineCapacity()+1
+}
+
+// writeLongWord writes w, which is wider than an entire line, according to the
+// long word policy. It starts w on a line of its own, and breaks it every time
+// the line is full.
+func (ww *Writer) writeLongWord(w string) (int, error) {
+	var tw int // total written
+
+	debug("writeLongWord(%q); %q (remaining: %d)\n", w, string(ww.lb.Bytes()), ww.remaining)
+
+	if ww.longWord == LongWordError {
+		return 0, fmt.Errorf("cannot write word %q wider than %d columns: %w", w, ww.capacity(), ErrWordTooLong)
+	}
+
+	var mark string
+	if ww.longWord == LongWordBreakWithMark {
+		mark = ww.mark
+	}
+
+	if !ww.lineEmpty() {
+		// Line buffer already has text on this line.
+		nw, err := ww.wrap()
+		tw += nw
+		if err != nil {
+			return tw, err
+		}
+	}
+
+	for {
+		c := ww.columns(w)
+		if c < ww.remaining {
+			// Remainder of the word fits on this line.
+			if _, err := ww.lb.WriteString(w); err != nil {
+				return tw, err
+			}
+			ww.remaining -= c
+			ww.trackEscapes(w)
+			return tw, nil
+		}
+
+		head, tail := ww.splitColumns(w, ww.remaining-1-ww.columns(mark))
+
+		if _, err := ww.lb.WriteString(head); err != nil {
+			return tw, err
+		}
+		ww.trackEscapes(head)
+		if _, err := ww.lb.WriteString(mark); err != nil {
+			return tw, err
+		}
+		ww.remaining -= ww.columns(head) + ww.columns(mark)
+
+		nw, err := ww.wrap()
+		tw += nw
+		if err != nil {
+			return tw, err
+		}
+
+		w = tail
+	}
+}
+
+// splitColumns splits s into a head that occupies no more than n columns, and
+// the tail that remains. Escape sequences are kept intact, and the head always
+// contains at least one extended grapheme cluster so callers make progress.
+func (ww *Writer) splitColumns(s string, n int) (string, string) {
+	var i, c int
+
+	for i < len(s) {
+		if l := escapeLength(s[i:]); l > 0 {
+			i += l
+			continue
+		}
+		g, _ := nextGrapheme(s[i:])
+		gc := ww.columns(g)
+		if c+gc > n && c > 0 {
+			break
+		}
+		c += gc
+		i += len(g)
+	}
+
+	return s[:i], s[i:]
+}
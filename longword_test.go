@@ -0,0 +1,158 @@
+package golinewrap_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/karrick/golinewrap"
+)
+
+func TestLongWordPolicy(t *testing.T) {
+	emit := func(t *testing.T, policy golinewrap.LongWordPolicy, width int, prefix string, p string) (string, error) {
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, width, prefix)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.SetLongWordPolicy(policy); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = lw.WriteParagraph(p)
+
+		return string(bb.Bytes()), err
+	}
+
+	t.Run("overflow", func(t *testing.T) {
+		got, err := emit(t, golinewrap.LongWordOverflow, 8, ">", "a abcdefghij b")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := ">a\n>abcdefghij\n>b\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("break", func(t *testing.T) {
+		got, err := emit(t, golinewrap.LongWordBreak, 8, ">", "a abcdefghijklmnop b")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := ">a\n>abcdef\n>ghijkl\n>mnop b\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("break exactly at width", func(t *testing.T) {
+		got, err := emit(t, golinewrap.LongWordBreak, 8, ">", "abcdefghijkl")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := ">abcdef\n>ghijkl\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("break wide characters", func(t *testing.T) {
+		got, err := emit(t, golinewrap.LongWordBreak, 8, ">", "一二三四五六七")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := ">一二三\n>四五六\n>七\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("break with mark", func(t *testing.T) {
+		got, err := emit(t, golinewrap.LongWordBreakWithMark, 8, ">", "abcdefghijklmnop")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := ">abcde-\n>fghij-\n>klmnop\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("break keeps style", func(t *testing.T) {
+		got, err := emit(t, golinewrap.LongWordBreak, 5, ">", "\x1b[31mabcdef\x1b[0m")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := ">\x1b[31mabc\x1b[0m\n>\x1b[31mdef\x1b[0m\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := emit(t, golinewrap.LongWordError, 8, ">", "a abcdefghij b")
+		if !errors.Is(err, golinewrap.ErrWordTooLong) {
+			t.Errorf("GOT: %v; WANT: %v", err, golinewrap.ErrWordTooLong)
+		}
+	})
+}
+
+func TestLongWordPolicyWithSuffix(t *testing.T) {
+	emit := func(t *testing.T, policy golinewrap.LongWordPolicy, p string) string {
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.NewWithOptions(bb,
+			golinewrap.WithWidth(12),
+			golinewrap.WithPrefix("| "),
+			golinewrap.WithSuffix(" |"),
+			golinewrap.WithLongWordPolicy(policy),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = lw.WriteParagraph(p); err != nil {
+			t.Fatal(err)
+		}
+
+		return string(bb.Bytes())
+	}
+
+	t.Run("break", func(t *testing.T) {
+		got := emit(t, golinewrap.LongWordBreak, "a abcdefghijklmnopqrstuvwxyz b")
+		if want := "| a       |\n| abcdefg |\n| hijklmn |\n| opqrstu |\n| vwxyz b |\n|         |\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("break with mark", func(t *testing.T) {
+		got := emit(t, golinewrap.LongWordBreakWithMark, "a abcdefghijklmnop b")
+		if want := "| a       |\n| abcdef- |\n| ghijkl- |\n| mnop b  |\n|         |\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+}
+
+func TestSetContinuationMark(t *testing.T) {
+	bb := new(bytes.Buffer)
+
+	lw, err := golinewrap.New(bb, 8, ">")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = lw.SetLongWordPolicy(golinewrap.LongWordBreakWithMark); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = lw.SetContinuationMark("123456"); err == nil {
+		t.Errorf("GOT: %v; WANT: error", err)
+	}
+
+	if err = lw.SetContinuationMark(" \\"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = lw.WriteParagraph("abcdefghij"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := string(bb.Bytes()), ">abcd \\\n>efghij\n>\n"; got != want {
+		t.Errorf("GOT: %q; WANT: %q", got, want)
+	}
+}
@@ -0,0 +1,47 @@
+package golinewrap
+
+import "fmt"
+
+// Newlines determines how Write interprets the newlines in its input.
+type Newlines int
+
+const (
+	// NewlineParagraph writes each line as a paragraph, followed by a blank
+	// line. This is the default.
+	NewlineParagraph Newlines = iota
+
+	// NewlineHard ends the output line at each newline, without a blank line,
+	// so the lines of a paragraph may be written one at a time. Each newline
+	// in the input ends exactly one output line, so an empty line in the input
+	// is written as a blank line.
+	NewlineHard
+
+	// NewlineSoft joins the lines of each paragraph with a space, reflowing
+	// text in which paragraphs are separated by one or more blank lines, as
+	// does ReflowReader.
+	NewlineSoft
+)
+
+// SetNewlines changes how Write interprets the newlines in its input.
+func (ww *Writer) SetNewlines(n Newlines) error {
+	if n != NewlineParagraph && n != NewlineHard && n != NewlineSoft {
+		return fmt.Errorf("cannot set unknown newlines: %d", n)
+	}
+	ww.newlines = n
+	return nil
+}
+
+// writeLine writes a complete line of the input given to Write, as its
+// newlines require.
+func (ww *Writer) writeLine(p string) error {
+	if ww.newlines != NewlineHard {
+		_, err := ww.WriteParagraph(p)
+		return err
+	}
+
+	if _, err := ww.writeText(p); err != nil {
+		return err
+	}
+	_, err := ww.newline()
+	return err
+}
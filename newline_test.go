@@ -0,0 +1,84 @@
+package golinewrap_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/karrick/golinewrap"
+)
+
+func TestSetNewlines(t *testing.T) {
+	emit := func(t *testing.T, newlines golinewrap.Newlines, streaming bool, bb ...string) string {
+		t.Helper()
+		out := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(out, 16, "> ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.SetNewlines(newlines); err != nil {
+			t.Fatal(err)
+		}
+		lw.SetStreaming(streaming)
+
+		for _, b := range bb {
+			nw, err := lw.Write([]byte(b))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := nw, len(b); got != want {
+				t.Errorf("GOT: %v; WANT: %v", got, want)
+			}
+		}
+		if err = lw.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		return string(out.Bytes())
+	}
+
+	t.Run("paragraph", func(t *testing.T) {
+		got := emit(t, golinewrap.NewlineParagraph, false, "a\nb\n")
+		if want := "> a\n>\n> b\n>\n>\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("hard", func(t *testing.T) {
+		got := emit(t, golinewrap.NewlineHard, false, "a\nb\n", "one two three four\n\nc")
+		if want := "> a\n> b\n> one two three\n> four\n>\n> c\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("hard streaming", func(t *testing.T) {
+		got := emit(t, golinewrap.NewlineHard, true, "a\nb", "\none two th", "ree four\n\nc")
+		if want := "> a\n> b\n> one two three\n> four\n>\n> c\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("soft", func(t *testing.T) {
+		got := emit(t, golinewrap.NewlineSoft, false, "one\ntwo\nthree\r\nfour\n\n\nfive\n")
+		if want := "> one two three\n> four\n>\n> five\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("soft streaming", func(t *testing.T) {
+		got := emit(t, golinewrap.NewlineSoft, true, "one\ntw", "o\nthree\r", "\nfour\n\n", "\nfive")
+		if want := "> one two three\n> four\n>\n> five\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		lw, err := golinewrap.New(new(bytes.Buffer), 16, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.SetNewlines(golinewrap.Newlines(42)); err == nil {
+			t.Errorf("GOT: %v; WANT: %v", err, "error")
+		}
+	})
+}
@@ -0,0 +1,249 @@
+package golinewrap
+
+import "fmt"
+
+// Breaking determines how WriteParagraph chooses where to break lines.
+type Breaking int
+
+const (
+	// BreakGreedy fills each line with as many words as fit before moving to
+	// the next line. This is the default.
+	BreakGreedy Breaking = iota
+
+	// BreakOptimal considers the whole paragraph, choosing the line breaks
+	// that minimize the total cost of the paragraph, as described by Knuth
+	// and Plass. The cost of each line but the last is the square of the
+	// number of unused columns, plus any penalties that apply to the line.
+	BreakOptimal
+)
+
+// Penalties are the costs that BreakOptimal adds to a line in addition to the
+// square of its unused columns. Larger penalties make the corresponding
+// breaks less likely.
+type Penalties struct {
+	// Hyphen is added to each line that ends with a hyphenated word.
+	Hyphen int
+
+	// ConsecutiveHyphens is added to each line that ends with a hyphenated
+	// word when the previous line did as well.
+	ConsecutiveHyphens int
+
+	// ShortLastLine is added when the last line of a paragraph of more than
+	// one line uses less than a quarter of the columns available.
+	ShortLastLine int
+}
+
+// DefaultPenalties are the Penalties a new Writer uses.
+var DefaultPenalties = Penalties{
+	Hyphen:             50,
+	ConsecutiveHyphens: 3000,
+	ShortLastLine:      200,
+}
+
+// overfull is the cost of a line that is wider than the columns available,
+// which is only considered when the rest of a single segment is too wide for
+// any line. Such a line never ends with a hyphen.
+const overfull = 1 << 30
+
+// SetBreaking changes how WriteParagraph chooses where to break lines. WriteWord
+// and WriteRune always break lines greedily, because they cannot look ahead.
+func (ww *Writer) SetBreaking(b Breaking) error {
+	if b != BreakGreedy && b != BreakOptimal {
+		return fmt.Errorf("cannot set unknown breaking: %d", b)
+	}
+	ww.breaking = b
+	return nil
+}
+
+// SetPenalties changes the costs BreakOptimal adds to lines that end with a
+// hyphen, and to a very short last line.
+func (ww *Writer) SetPenalties(p Penalties) {
+	ww.penalties = p
+}
+
+// fragment is a piece of a paragraph that is never broken by BreakOptimal.
+// Segments are split into fragments at the points their hyphenation permits.
+type fragment struct {
+	text    string
+	columns int
+	hyphen  bool // line may break after fragment, with a hyphen
+	space   bool // fragment ends a word, so is followed by a space
+}
+
+// fragments returns the fragments of the segments of p.
+func (ww *Writer) fragments(p string) []fragment {
+	var ff []fragment
+
+	for _, s := range segments(p) {
+		points := s.shy
+		if len(points) == 0 {
+			points = ww.hyphenationPoints(s.text)
+		}
+
+		var last int
+		for _, i := range points {
+			ff = append(ff, fragment{text: s.text[last:i], columns: ww.columns(s.text[last:i]), hyphen: true})
+			last = i
+		}
+		ff = append(ff, fragment{text: s.text[last:], columns: ww.columns(s.text[last:]), space: s.space})
+	}
+
+	return ff
+}
+
+// breaks returns the indexes of the fragments that begin each line after the
+// first, chosen to minimize the total cost of the paragraph. The first line
+// has first columns available, and the others have rest columns available.
+func (ww *Writer) breaks(ff []fragment, first, rest int) []int {
+	n := len(ff)
+
+	cost := make([]int, n+1) // cost[j] is lowest cost of lines before fragment j
+	prev := make([]int, n+1) // prev[j] is start of line ending before fragment j
+	for j := 1; j <= n; j++ {
+		cost[j] = -1
+	}
+
+	for i := 0; i < n; i++ {
+		if cost[i] < 0 {
+			continue // no line ends before fragment i
+		}
+
+		available := rest
+		if i == 0 {
+			available = first
+		}
+
+		var width int
+		var fits bool // whether a line from fragment i fits
+		for j := i + 1; j <= n; j++ {
+			f := ff[j-1]
+			if j > i+1 && ff[j-2].space {
+				width++ // space between words
+			}
+			width += f.columns
+
+			// A line that does not fit holds no more than one segment.
+			if width > available && (fits || j > i+1 && !ff[j-2].hyphen) {
+				break
+			}
+			used := width
+			if j < n && f.hyphen {
+				used++ // hyphen
+			}
+
+			var c int
+			switch {
+			case used > available:
+				if j < n && f.hyphen {
+					continue // too wide to hyphenate here
+				}
+				c = overfull
+			case j == n:
+				fits = true
+				if i > 0 && used*4 < available {
+					c = ww.penalties.ShortLastLine
+				}
+			default:
+				fits = true
+				slack := available - used
+				c = slack * slack
+				if f.hyphen {
+					c += ww.penalties.Hyphen
+					if i > 0 && ff[i-1].hyphen {
+						c += ww.penalties.ConsecutiveHyphens
+					}
+				}
+			}
+
+			if c += cost[i]; cost[j] < 0 || c < cost[j] {
+				cost[j] = c
+				prev[j] = i
+			}
+		}
+	}
+
+	var starts []int
+	for j := prev[n]; j > 0; j = prev[j] {
+		starts = append([]int{j}, starts...)
+	}
+
+	return starts
+}
+
+// writeOptimal writes the words of p, breaking lines where BreakOptimal
+// chooses, leaving the last line of p in the line buffer.
+func (ww *Writer) writeOptimal(p string) (int, error) {
+	var tw int // total written
+
+	ff := ww.fragments(p)
+	if len(ff) == 0 {
+		return 0, nil
+	}
+
+	// The line buffer may already hold words written by WriteWord.
+	if !ww.lineEmpty() && ff[0].columns >= ww.remaining {
+		nw, err := ww.wrap()
+		tw += nw
+		if err != nil {
+			return tw, err
+		}
+	}
+
+	starts := append(ww.breaks(ff, ww.remaining-1, ww.capacity()), len(ff))
+
+	var i int
+	for _, j := range starts {
+		var columns int
+		for k := i; k < j; k++ {
+			if k > i && ff[k-1].space {
+				columns++ // space between words
+			}
+			columns += ff[k].columns
+		}
+
+		if columns > ww.remaining-1 && ww.longWord != LongWordOverflow {
+			// The rest of a single segment too wide for any line.
+			var word string
+			for k := i; k < j; k++ {
+				word += ff[k].text
+			}
+			nw, err := ww.writeLongWord(word)
+			tw += nw
+			if err != nil {
+				return tw, err
+			}
+		} else {
+			for k := i; k < j; k++ {
+				if k > i && ff[k-1].space {
+					if err := ww.lb.WriteByte(' '); err != nil {
+						return tw, err
+					}
+					ww.remaining--
+				}
+				if _, err := ww.lb.WriteString(ff[k].text); err != nil {
+					return tw, err
+				}
+				ww.remaining -= ff[k].columns
+				ww.trackEscapes(ff[k].text)
+			}
+		}
+
+		if j == len(ff) {
+			break
+		}
+		if ff[j-1].hyphen {
+			if err := ww.lb.WriteByte('-'); err != nil {
+				return tw, err
+			}
+			ww.remaining--
+		}
+		nw, err := ww.wrap()
+		tw += nw
+		if err != nil {
+			return tw, err
+		}
+		i = j
+	}
+
+	return tw, nil
+}
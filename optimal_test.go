@@ -0,0 +1,167 @@
+package golinewrap_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/karrick/golinewrap"
+)
+
+func TestBreakOptimal(t *testing.T) {
+	emit := func(t *testing.T, width int, prefix string, h *golinewrap.Hyphenator, penalties golinewrap.Penalties, p string) string {
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, width, prefix)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.SetBreaking(golinewrap.BreakOptimal); err != nil {
+			t.Fatal(err)
+		}
+		lw.SetHyphenator(h)
+		lw.SetPenalties(penalties)
+
+		if _, err = lw.WriteParagraph(p); err != nil {
+			t.Fatal(err)
+		}
+
+		return string(bb.Bytes())
+	}
+
+	t.Run("balances lines", func(t *testing.T) {
+		got := emit(t, 8, ">", nil, golinewrap.DefaultPenalties, "aaa bb cc ddddd")
+		if want := ">aaa\n>bb cc\n>ddddd\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("hyphenates when cheaper", func(t *testing.T) {
+		got := emit(t, 16, ">", golinewrap.English(), golinewrap.DefaultPenalties, "aaaa hyphenation")
+		if want := ">aaaa hyphen-\n>ation\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("hyphenates at soft hyphen", func(t *testing.T) {
+		got := emit(t, 16, ">", nil, golinewrap.DefaultPenalties, "aaaa hy\u00adphen\u00adation")
+		if want := ">aaaa hyphen-\n>ation\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("hyphen penalty", func(t *testing.T) {
+		got := emit(t, 16, ">", golinewrap.English(), golinewrap.Penalties{Hyphen: 1000}, "aaaa hyphenation")
+		if want := ">aaaa\n>hyphenation\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("short last line penalty", func(t *testing.T) {
+		const p = "aaaaa bbbbb ccccc ddddd e"
+
+		got := emit(t, 12, "", nil, golinewrap.Penalties{}, p)
+		if want := "aaaaa bbbbb\nccccc ddddd\ne\n\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+
+		got = emit(t, 12, "", nil, golinewrap.Penalties{ShortLastLine: 200}, p)
+		if want := "aaaaa\nbbbbb ccccc\nddddd e\n\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("word wider than line", func(t *testing.T) {
+		got := emit(t, 8, ">", nil, golinewrap.DefaultPenalties, "a abcdefghij b")
+		if want := ">a\n>abcdefghij\n>b\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("invalid breaking", func(t *testing.T) {
+		lw, err := golinewrap.New(new(bytes.Buffer), 8, ">")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.SetBreaking(golinewrap.Breaking(42)); err == nil {
+			t.Errorf("GOT: %v; WANT: error", err)
+		}
+	})
+}
+
+func TestBreakOptimalWithSuffix(t *testing.T) {
+	bb := new(bytes.Buffer)
+
+	lw, err := golinewrap.NewWithOptions(bb,
+		golinewrap.WithWidth(16),
+		golinewrap.WithPrefix("| "),
+		golinewrap.WithSuffix(" |"),
+		golinewrap.WithBreaking(golinewrap.BreakOptimal),
+		golinewrap.WithHyphenator(golinewrap.English()),
+		golinewrap.WithPenalties(golinewrap.Penalties{}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = lw.WriteParagraph("the hyphenation of words"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := string(bb.Bytes()), "| the hyphen- |\n| ation of    |\n| words       |\n|             |\n"; got != want {
+		t.Errorf("GOT: %q; WANT: %q", got, want)
+	}
+}
+
+func TestBreakOptimalLongWord(t *testing.T) {
+	emit := func(t *testing.T, policy golinewrap.LongWordPolicy, p string) (string, error) {
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.NewWithOptions(bb,
+			golinewrap.WithWidth(8),
+			golinewrap.WithPrefix("> "),
+			golinewrap.WithSuffix(" |"),
+			golinewrap.WithBreaking(golinewrap.BreakOptimal),
+			golinewrap.WithHyphenator(golinewrap.English()),
+			golinewrap.WithLongWordPolicy(policy),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = lw.WriteParagraph(p)
+		return string(bb.Bytes()), err
+	}
+
+	t.Run("break", func(t *testing.T) {
+		got, err := emit(t, golinewrap.LongWordBreak, "ccc-hyphenationtypesetting")
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := "> ccc |\n> -   |\n> hy- |\n> phe |\n> nat |\n> ion |\n> typ |\n> ese |\n> tti |\n> ng  |\n>     |\n"
+		if got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("break with mark", func(t *testing.T) {
+		got, err := emit(t, golinewrap.LongWordBreakWithMark, "ab setting")
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := "> ab  |\n> se- |\n> tt- |\n> ing |\n>     |\n"
+		if got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		got, err := emit(t, golinewrap.LongWordError, "ab setting")
+		if !errors.Is(err, golinewrap.ErrWordTooLong) {
+			t.Errorf("GOT: %v; WANT: %v", err, golinewrap.ErrWordTooLong)
+		}
+		if want := "> ab  |\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+}
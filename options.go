@@ -0,0 +1,239 @@
+package golinewrap
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrWidthTooSmall is matched, using errors.Is, by every WidthError.
+var ErrWidthTooSmall = errors.New("width too small")
+
+// WidthError is returned when the width is not greater than zero, or is not
+// greater than the number of columns used by a prefix and the suffix. Use
+// errors.As to examine it, or errors.Is with ErrWidthTooSmall to detect it.
+type WidthError struct {
+	Op      string // what could not be done, such as "create Writer"
+	Width   int    // width of the Writer
+	Used    string // what uses the columns, such as "prefix and suffix"
+	Columns int    // number of columns used
+}
+
+// Error returns a description of the error.
+func (e *WidthError) Error() string {
+	if e.Width <= 0 {
+		return fmt.Sprintf("cannot %s unless width (%d) is greater than zero.", e.Op, e.Width)
+	}
+	return fmt.Sprintf("cannot %s unless width (%d) is greater than number of columns used by %s: %d.", e.Op, e.Width, e.Used, e.Columns)
+}
+
+// Is returns true when target is ErrWidthTooSmall.
+func (e *WidthError) Is(target error) bool {
+	return target == ErrWidthTooSmall
+}
+
+// config is the configuration of a Writer built by the Options given to
+// NewWithOptions.
+type config struct {
+	width        int
+	prefix       string
+	firstPrefix  string
+	hanging      bool
+	prefixFunc   PrefixFunc
+	suffix       string
+	measure      Measure
+	longWord     LongWordPolicy
+	mark         string
+	hyphenator   *Hyphenator
+	breaking     Breaking
+	penalties    Penalties
+	alignment    Alignment
+	preformatted PreformattedPolicy
+	newlines     Newlines
+	streaming    bool
+	tabWidth     int
+	tabOrigin    TabOrigin
+	watchResize  bool
+}
+
+// Option configures a Writer created by NewWithOptions. Options may be given
+// in any order, because the configuration is validated once all of them have
+// been applied.
+type Option func(*config)
+
+// WithWidth sets the number of columns to wrap text to, including the column
+// used by the newline character. It is required.
+func WithWidth(width int) Option {
+	return func(c *config) { c.width = width }
+}
+
+// WithPrefix sets the prefix written at the start of every line.
+func WithPrefix(prefix string) Option {
+	return func(c *config) { c.prefix = prefix }
+}
+
+// WithFirstLinePrefix sets the prefix written at the start of the first line of
+// each paragraph, as SetFirstLinePrefix does.
+func WithFirstLinePrefix(prefix string) Option {
+	return func(c *config) { c.firstPrefix, c.hanging = prefix, true }
+}
+
+// WithPrefixFunc sets the function that builds the prefix of each line, as
+// SetPrefixFunc does.
+func WithPrefixFunc(fn PrefixFunc) Option {
+	return func(c *config) { c.prefixFunc = fn }
+}
+
+// WithSuffix sets the suffix written at the right edge of every line, as
+// SetSuffix does.
+func WithSuffix(suffix string) Option {
+	return func(c *config) { c.suffix = suffix }
+}
+
+// WithMeasure sets how columns are counted, as SetMeasure does.
+func WithMeasure(m Measure) Option {
+	return func(c *config) { c.measure = m }
+}
+
+// WithLongWordPolicy sets what is done with words wider than a line, as
+// SetLongWordPolicy does.
+func WithLongWordPolicy(p LongWordPolicy) Option {
+	return func(c *config) { c.longWord = p }
+}
+
+// WithContinuationMark sets the mark appended to each line of a broken long
+// word, as SetContinuationMark does.
+func WithContinuationMark(mark string) Option {
+	return func(c *config) { c.mark = mark }
+}
+
+// WithHyphenator sets the Hyphenator used to hyphenate words, as
+// SetHyphenator does.
+func WithHyphenator(h *Hyphenator) Option {
+	return func(c *config) { c.hyphenator = h }
+}
+
+// WithBreaking sets how WriteParagraph chooses where to break lines, as
+// SetBreaking does.
+func WithBreaking(b Breaking) Option {
+	return func(c *config) { c.breaking = b }
+}
+
+// WithPenalties sets the costs BreakOptimal adds to lines, as SetPenalties
+// does.
+func WithPenalties(p Penalties) Option {
+	return func(c *config) { c.penalties = p }
+}
+
+// WithAlignment sets how lines are aligned, as SetAlignment does.
+func WithAlignment(a Alignment) Option {
+	return func(c *config) { c.alignment = a }
+}
+
+// WithPreformattedPolicy sets what WritePreformatted does with lines wider than
+// the width, as SetPreformattedPolicy does.
+func WithPreformattedPolicy(p PreformattedPolicy) Option {
+	return func(c *config) { c.preformatted = p }
+}
+
+// WithNewlines sets how Write interprets newlines, as SetNewlines does.
+func WithNewlines(n Newlines) Option {
+	return func(c *config) { c.newlines = n }
+}
+
+// WithStreaming sets whether Write treats its input as a stream, as
+// SetStreaming does.
+func WithStreaming(streaming bool) Option {
+	return func(c *config) { c.streaming = streaming }
+}
+
+// WithTabWidth sets the number of columns between tab stops, as SetTabWidth
+// does.
+func WithTabWidth(n int) Option {
+	return func(c *config) { c.tabWidth = n }
+}
+
+// WithTabOrigin sets the column from which tab stops are measured, as
+// SetTabOrigin does.
+func WithTabOrigin(o TabOrigin) Option {
+	return func(c *config) { c.tabOrigin = o }
+}
+
+// NewWithOptions returns a new Writer that writes to w, configured by opts.
+// WithWidth is required. It returns a WidthError unless the width is greater
+// than the number of columns used by each prefix together with the suffix, and
+// an error when any other option is invalid.
+func NewWithOptions(w io.Writer, opts ...Option) (*Writer, error) {
+	c := config{mark: "-", penalties: DefaultPenalties, tabWidth: 8}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	if c.width <= 0 {
+		return nil, &WidthError{Op: "create Writer", Width: c.width}
+	}
+	if c.measure != MeasureCells && c.measure != MeasureRunes {
+		return nil, fmt.Errorf("cannot set unknown measure: %d", c.measure)
+	}
+
+	prefixColumns := measure(c.measure, c.prefix)
+	firstColumns := measure(c.measure, c.firstPrefix)
+	suffixColumns := measure(c.measure, c.suffix)
+
+	if c.width <= prefixColumns+suffixColumns {
+		return nil, &WidthError{Op: "create Writer", Width: c.width, Used: "prefix and suffix", Columns: prefixColumns + suffixColumns}
+	}
+	if c.width <= firstColumns+suffixColumns {
+		return nil, &WidthError{Op: "create Writer", Width: c.width, Used: "first line prefix and suffix", Columns: firstColumns + suffixColumns}
+	}
+
+	// NOTE: The line buffer is sized for single byte runes, and will be
+	// extended as required when runes that require more than a single byte are
+	// emitted.
+
+	ww := &Writer{
+		Writer:        w,
+		lb:            bytes.NewBuffer(make([]byte, 0, c.width+1)),
+		max:           c.width,
+		prefix:        c.prefix,
+		prefixColumns: prefixColumns,
+		firstPrefix:   c.firstPrefix,
+		firstColumns:  firstColumns,
+		hanging:       c.hanging,
+		prefixFunc:    c.prefixFunc,
+		suffix:        c.suffix,
+		suffixColumns: suffixColumns,
+		remaining:     c.width - suffixColumns,
+		first:         true,
+		measure:       c.measure,
+		hyphenator:    c.hyphenator,
+		penalties:     c.penalties,
+		streaming:     c.streaming,
+	}
+
+	for _, err := range []error{
+		ww.SetLongWordPolicy(c.longWord),
+		ww.SetContinuationMark(c.mark),
+		ww.SetBreaking(c.breaking),
+		ww.SetAlignment(c.alignment),
+		ww.SetPreformattedPolicy(c.preformatted),
+		ww.SetNewlines(c.newlines),
+		ww.SetTabWidth(c.tabWidth),
+		ww.SetTabOrigin(c.tabOrigin),
+	} {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := ww.writePrefix(); err != nil {
+		return nil, err
+	}
+
+	if c.watchResize {
+		ww.watchResize()
+	}
+
+	return ww, nil
+}
@@ -0,0 +1,77 @@
+package golinewrap_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/karrick/golinewrap"
+)
+
+func TestNewWithOptions(t *testing.T) {
+	t.Run("options", func(t *testing.T) {
+		bb := new(bytes.Buffer)
+
+		// Options may be given in any order.
+		lw, err := golinewrap.NewWithOptions(bb,
+			golinewrap.WithSuffix(" |"),
+			golinewrap.WithFirstLinePrefix("- "),
+			golinewrap.WithPrefix("  "),
+			golinewrap.WithWidth(16),
+			golinewrap.WithAlignment(golinewrap.AlignRight),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = lw.WriteParagraph("one two three four"); err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := string(bb.Bytes()), "-     one two |\n   three four |\n              |\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("width required", func(t *testing.T) {
+		_, err := golinewrap.NewWithOptions(new(bytes.Buffer), golinewrap.WithPrefix("> "))
+		if want := golinewrap.ErrWidthTooSmall; !errors.Is(err, want) {
+			t.Errorf("GOT: %v; WANT: %v", err, want)
+		}
+	})
+
+	t.Run("width too small", func(t *testing.T) {
+		_, err := golinewrap.NewWithOptions(new(bytes.Buffer),
+			golinewrap.WithWidth(8),
+			golinewrap.WithFirstLinePrefix("12345"),
+			golinewrap.WithSuffix("123"),
+		)
+		var we *golinewrap.WidthError
+		if !errors.As(err, &we) {
+			t.Fatalf("GOT: %v; WANT: %T", err, we)
+		}
+		if got, want := *we, (golinewrap.WidthError{Op: "create Writer", Width: 8, Used: "first line prefix and suffix", Columns: 8}); got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	})
+
+	t.Run("invalid option", func(t *testing.T) {
+		_, err := golinewrap.NewWithOptions(new(bytes.Buffer),
+			golinewrap.WithWidth(8),
+			golinewrap.WithAlignment(golinewrap.Alignment(42)),
+		)
+		if err == nil || errors.Is(err, golinewrap.ErrWidthTooSmall) {
+			t.Errorf("GOT: %v; WANT: %v", err, "error")
+		}
+	})
+
+	t.Run("setter width error", func(t *testing.T) {
+		lw, err := golinewrap.New(new(bytes.Buffer), 8, "1234")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.SetSuffix("5678"); !errors.Is(err, golinewrap.ErrWidthTooSmall) {
+			t.Errorf("GOT: %v; WANT: %v", err, golinewrap.ErrWidthTooSmall)
+		}
+	})
+}
@@ -0,0 +1,71 @@
+package golinewrap
+
+import (
+	"errors"
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+)
+
+// BeginParagraph starts building a paragraph from many pieces. The pieces given
+// to AddWord, AddString, and AddPrintf are held by the Writer, and nothing is
+// written until EndParagraph is invoked, at which point the pieces are written
+// as a single paragraph, exactly as if they had been concatenated and given to
+// WriteParagraph. Words already written to the current line by WriteWord,
+// along with the single space WriteWord leaves after each word, become the
+// start of the paragraph. It returns an error unless the previous paragraph
+// has been ended.
+func (ww *Writer) BeginParagraph() error {
+	if ww.building {
+		return errors.New("cannot begin paragraph unless previous paragraph has been ended")
+	}
+	ww.building = true
+	ww.pb.Reset()
+	return nil
+}
+
+// AddWord adds w to the paragraph being built, separated from any text before
+// it by a space, unless that text already ends with white space. It returns an
+// error unless a paragraph has been begun.
+func (ww *Writer) AddWord(w string) error {
+	if !ww.building {
+		return errors.New("cannot add word unless paragraph has been begun")
+	}
+	if ww.pb.Len() > 0 {
+		if r, _ := utf8.DecodeLastRuneInString(ww.pb.String()); !unicode.IsSpace(r) {
+			ww.pb.WriteByte(' ')
+		}
+	}
+	ww.pb.WriteString(w)
+	return nil
+}
+
+// AddString adds s to the paragraph being built as is, without separating it
+// from any text before it, so a word may be built from several strings. It
+// returns an error unless a paragraph has been begun.
+func (ww *Writer) AddString(s string) error {
+	if !ww.building {
+		return errors.New("cannot add string unless paragraph has been begun")
+	}
+	ww.pb.WriteString(s)
+	return nil
+}
+
+// AddPrintf formats its arguments using fmt.Sprintf and adds the resulting
+// string to the paragraph being built as AddString does. It returns an error
+// unless a paragraph has been begun.
+func (ww *Writer) AddPrintf(format string, a ...interface{}) error {
+	return ww.AddString(fmt.Sprintf(format, a...))
+}
+
+// EndParagraph writes the paragraph being built, followed by a blank line, as
+// WriteParagraph does. It returns an error unless a paragraph has been begun.
+func (ww *Writer) EndParagraph() (int, error) {
+	if !ww.building {
+		return 0, errors.New("cannot end paragraph unless paragraph has been begun")
+	}
+	ww.building = false
+	p := ww.pb.String()
+	ww.pb.Reset()
+	return ww.WriteParagraph(p)
+}
@@ -0,0 +1,95 @@
+package golinewrap_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/karrick/golinewrap"
+)
+
+func TestBuildParagraph(t *testing.T) {
+	t.Run("pieces", func(t *testing.T) {
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, 16, "# ")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = lw.BeginParagraph(); err != nil {
+			t.Fatal(err)
+		}
+		for _, w := range []string{"one", "two", "three"} {
+			if err = lw.AddWord(w); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err = lw.AddPrintf(" %d", 4); err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.AddString("th"); err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.AddWord("five"); err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := string(bb.Bytes()), ""; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+
+		if _, err = lw.EndParagraph(); err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := string(bb.Bytes()), "# one two three\n# 4th five\n#\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("continues words", func(t *testing.T) {
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, 16, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = lw.WriteWord("one"); err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.BeginParagraph(); err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.AddWord("two"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = lw.EndParagraph(); err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := string(bb.Bytes()), "one two\n\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		lw, err := golinewrap.New(new(bytes.Buffer), 16, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = lw.AddWord("one"); err == nil {
+			t.Errorf("GOT: %v; WANT: %v", err, "error")
+		}
+		if _, err = lw.EndParagraph(); err == nil {
+			t.Errorf("GOT: %v; WANT: %v", err, "error")
+		}
+		if err = lw.BeginParagraph(); err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.BeginParagraph(); err == nil {
+			t.Errorf("GOT: %v; WANT: %v", err, "error")
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package golinewrap
+
+// LineInfo describes the line for which a PrefixFunc builds a prefix.
+type LineInfo struct {
+	Line      int  // line number, starting at 1
+	Paragraph int  // paragraph number, starting at 1
+	First     bool // whether line is first line of its paragraph
+}
+
+// PrefixFunc returns the prefix for the line described by its argument. It is
+// called once for each line, when the line before it ends, and again only when
+// a change to the prefixes takes effect on a line with no text yet.
+type PrefixFunc func(LineInfo) string
+
+// SetPrefixFunc causes the Writer to call fn to build the prefix of each line,
+// replacing the prefixes given to New and SetFirstLinePrefix, which are used
+// again when fn is nil. It takes effect with the next line, or with the
+// current one when nothing has been written to it yet. Because each prefix is
+// measured when it is built, the Writer returns an error when writing a line
+// whose prefix uses as many columns as the width. When deciding whether a word
+// is wider than an entire line, the Writer assumes following lines have a
+// prefix as wide as that of the current line.
+func (ww *Writer) SetPrefixFunc(fn PrefixFunc) error {
+	ww.prefixFunc = fn
+	return ww.rewritePrefix()
+}
+
+// SetPrefix changes the prefix written at the start of every line, or of every
+// line but the first of each paragraph when SetFirstLinePrefix has been
+// called. It takes effect with the next line, or with the current one when
+// nothing has been written to it yet, so text already written to the current
+// line is kept. It returns a WidthError unless the width is greater than the
+// number of columns used by prefix together with the suffix.
+func (ww *Writer) SetPrefix(prefix string) error {
+	prefixColumns := measure(ww.measure, prefix)
+	if width := ww.width(); width <= prefixColumns+ww.indentColumns+ww.suffixColumns {
+		return &WidthError{Op: "set prefix", Width: width, Used: "prefix and suffix", Columns: prefixColumns + ww.indentColumns + ww.suffixColumns}
+	}
+
+	ww.prefix = prefix
+	ww.prefixColumns = prefixColumns
+
+	return ww.rewritePrefix()
+}
+
+// SetSuffix sets a suffix that is written at the right edge of every line,
+// after padding the line with spaces so the suffix ends at the width, less the
+// column reserved for the newline character. Together with a prefix, this
+// makes it possible to draw boxed comment blocks and bordered panels. It
+// returns an error unless width is greater than the number of columns used by
+// each prefix together with the suffix.
+func (ww *Writer) SetSuffix(suffix string) error {
+	suffixColumns := measure(ww.measure, suffix)
+
+	// Following lines use the width set by SetWidth, when there is one.
+	width := ww.width()
+	for _, prefixColumns := range []int{ww.prefixColumns, ww.firstColumns} {
+		if width <= prefixColumns+ww.indentColumns+suffixColumns {
+			return &WidthError{Op: "set suffix", Width: width, Used: "prefix and suffix", Columns: prefixColumns + ww.indentColumns + suffixColumns}
+		}
+	}
+
+	// The columns used by the prefix of the current line include its
+	// indentation.
+	if ww.max <= ww.lineColumns+suffixColumns {
+		return &WidthError{Op: "set suffix", Width: ww.max, Used: "prefix and suffix", Columns: ww.lineColumns + suffixColumns}
+	}
+
+	// Adjust the remaining columns in the current line for the change in the
+	// number of columns the suffix uses.
+	ww.remaining += ww.suffixColumns - suffixColumns
+	ww.suffix = suffix
+	ww.suffixColumns = suffixColumns
+
+	return nil
+}
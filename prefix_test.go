@@ -0,0 +1,222 @@
+package golinewrap_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/karrick/golinewrap"
+)
+
+func TestSetPrefixFunc(t *testing.T) {
+	emit := func(t *testing.T, width int, fn golinewrap.PrefixFunc, pp ...string) (string, error) {
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, width, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.SetPrefixFunc(fn); err != nil {
+			return "", err
+		}
+
+		for _, p := range pp {
+			if _, err = lw.WriteParagraph(p); err != nil {
+				return string(bb.Bytes()), err
+			}
+		}
+
+		return string(bb.Bytes()), nil
+	}
+
+	t.Run("line numbers", func(t *testing.T) {
+		got, err := emit(t, 12, func(li golinewrap.LineInfo) string {
+			return fmt.Sprintf("%2d ", li.Line)
+		}, "one two three")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := " 1 one two\n 2 three\n 3\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("paragraph and first line", func(t *testing.T) {
+		got, err := emit(t, 12, func(li golinewrap.LineInfo) string {
+			if li.First {
+				return fmt.Sprintf("%d. ", li.Paragraph)
+			}
+			return "   "
+		}, "one two three", "four")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "1. one two\n   three\n\n2. four\n\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("prefix measured each line", func(t *testing.T) {
+		got, err := emit(t, 12, func(li golinewrap.LineInfo) string {
+			return fmt.Sprintf("%*s", li.Line, ">")
+		}, "aaa bbb ccc ddd")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := ">aaa bbb\n >ccc ddd\n  >\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("called once for each line", func(t *testing.T) {
+		var calls []golinewrap.LineInfo
+		_, err := emit(t, 12, func(li golinewrap.LineInfo) string {
+			calls = append(calls, li)
+			return "> "
+		}, "one two three", "four")
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []golinewrap.LineInfo{
+			{Line: 1, Paragraph: 1, First: true},
+			{Line: 2, Paragraph: 1},
+			{Line: 3, Paragraph: 1},
+			{Line: 4, Paragraph: 2, First: true},
+			{Line: 5, Paragraph: 2},
+			{Line: 6, Paragraph: 3, First: true},
+		}
+		if got := fmt.Sprint(calls); got != fmt.Sprint(want) {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	})
+
+	t.Run("error when too long", func(t *testing.T) {
+		_, err := emit(t, 12, func(li golinewrap.LineInfo) string {
+			return "this prefix is too long"
+		})
+		if err == nil {
+			t.Errorf("GOT: %v; WANT: error", err)
+		}
+	})
+}
+
+func TestSetSuffix(t *testing.T) {
+	emit := func(t *testing.T, width int, prefix, suffix string, a golinewrap.Alignment, p string) string {
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, width, prefix)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.SetSuffix(suffix); err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.SetAlignment(a); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = lw.WriteParagraph(p); err != nil {
+			t.Fatal(err)
+		}
+
+		return string(bb.Bytes())
+	}
+
+	t.Run("error when too long", func(t *testing.T) {
+		lw, err := golinewrap.New(new(bytes.Buffer), 8, "/* ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.SetSuffix(" */ "); err != nil {
+			t.Errorf("GOT: %v; WANT: %v", err, nil)
+		}
+		if err = lw.SetSuffix(" */  "); err == nil {
+			t.Errorf("GOT: %v; WANT: error", err)
+		}
+	})
+
+	t.Run("boxed comment", func(t *testing.T) {
+		got := emit(t, 17, "/* ", " */", golinewrap.AlignLeft, "one two three four")
+		if want := "/* one two    */\n/* three four */\n/*            */\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("with alignment", func(t *testing.T) {
+		got := emit(t, 17, "| ", " |", golinewrap.AlignCenter, "one two three four")
+		if want := "|   one two    |\n|  three four  |\n|              |\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+
+		got = emit(t, 14, "| ", " |", golinewrap.AlignJustify, "ab cd ghijklmn op")
+		if want := "| ab     cd |\n| ghijklmn  |\n| op        |\n|           |\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("styled text", func(t *testing.T) {
+		got := emit(t, 11, "|", "|", golinewrap.AlignLeft, "\x1b[1mone two\x1b[0m")
+		if want := "|\x1b[1mone two\x1b[0m |\n|        |\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+}
+
+func TestSetPrefix(t *testing.T) {
+	t.Run("next line", func(t *testing.T) {
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, 12, "> ")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = lw.WriteWord("one"); err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.SetPrefix("    "); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = lw.WriteParagraph("two three"); err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := string(bb.Bytes()), "> one two\n    three\n\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("between sections", func(t *testing.T) {
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, 12, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = lw.WriteParagraph("Section"); err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.SetPrefix("  "); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = lw.WriteParagraph("one two three"); err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := string(bb.Bytes()), "Section\n\n  one two\n  three\n\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("too wide", func(t *testing.T) {
+		lw, err := golinewrap.New(new(bytes.Buffer), 12, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.SetPrefix("123456789012"); !errors.Is(err, golinewrap.ErrWidthTooSmall) {
+			t.Errorf("GOT: %v; WANT: %v", err, golinewrap.ErrWidthTooSmall)
+		}
+	})
+}
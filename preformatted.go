@@ -0,0 +1,98 @@
+package golinewrap
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PreformattedPolicy determines what WritePreformatted does with a line that
+// is wider than the width.
+type PreformattedPolicy int
+
+const (
+	// PreformattedOverflow emits each line as is, allowing it to overflow the
+	// width. This is the default.
+	PreformattedOverflow PreformattedPolicy = iota
+
+	// PreformattedBreak breaks each line at the width, ending each broken
+	// line with the continuation mark, which is a hyphen unless changed by
+	// SetContinuationMark.
+	PreformattedBreak
+)
+
+// SetPreformattedPolicy changes what WritePreformatted does with a line that
+// is wider than the width.
+func (ww *Writer) SetPreformattedPolicy(p PreformattedPolicy) error {
+	if p != PreformattedOverflow && p != PreformattedBreak {
+		return fmt.Errorf("cannot set unknown preformatted policy: %d", p)
+	}
+	ww.preformatted = p
+	return nil
+}
+
+// WritePreformatted writes each line of s verbatim, preserving its white
+// space, with the prefix applied, and without blank lines between them. Tabs
+// are expanded to spaces, as configured by SetTabWidth and SetTabOrigin. It is
+// meant for code samples and other text that must not be reflowed. Lines that
+// are wider than the width are handled according to the preformatted policy.
+// When the current line already has text, s begins on the next line.
+func (ww *Writer) WritePreformatted(s string) (int, error) {
+	var tw int // total written
+
+	debug("WritePreformatted(%q): %q; %d\n", s, string(ww.lb.Bytes()), ww.remaining)
+
+	if !ww.lineEmpty() {
+		nw, err := ww.newline()
+		tw += nw
+		if err != nil {
+			return tw, err
+		}
+	}
+
+	ww.verbatim = true
+	defer func() { ww.verbatim = false }()
+
+	for _, line := range strings.Split(strings.TrimSuffix(s, "\n"), "\n") {
+		nw, err := ww.writePreformattedLine(line)
+		tw += nw
+		if err != nil {
+			return tw, err
+		}
+	}
+
+	return tw, nil
+}
+
+// writePreformattedLine writes line verbatim, followed by a newline.
+func (ww *Writer) writePreformattedLine(line string) (int, error) {
+	var tw int // total written
+
+	line = ww.expandTabs(line)
+
+	for ww.preformatted == PreformattedBreak && ww.columns(line) >= ww.remaining {
+		head, tail := ww.splitColumns(line, ww.remaining-1-ww.columns(ww.mark))
+
+		if _, err := ww.lb.WriteString(head + ww.mark); err != nil {
+			return tw, err
+		}
+		ww.remaining -= ww.columns(head) + ww.columns(ww.mark)
+		ww.trackEscapes(head)
+
+		nw, err := ww.wrap()
+		tw += nw
+		if err != nil {
+			return tw, err
+		}
+
+		line = tail
+	}
+
+	if _, err := ww.lb.WriteString(line); err != nil {
+		return tw, err
+	}
+	ww.remaining -= ww.columns(line)
+	ww.trackEscapes(line)
+
+	nw, err := ww.newline()
+	return tw + nw, err
+}
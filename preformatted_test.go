@@ -0,0 +1,103 @@
+package golinewrap_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/karrick/golinewrap"
+)
+
+func TestWritePreformatted(t *testing.T) {
+	emit := func(t *testing.T, width int, prefix string, policy golinewrap.PreformattedPolicy, words []string, text string) string {
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, width, prefix)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.SetPreformattedPolicy(policy); err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.SetContinuationMark("\\"); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, w := range words {
+			if _, err = lw.WriteWord(w); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if _, err = lw.WritePreformatted(text); err != nil {
+			t.Fatal(err)
+		}
+
+		return string(bb.Bytes())
+	}
+
+	t.Run("preserves white space", func(t *testing.T) {
+		got := emit(t, 20, "> ", golinewrap.PreformattedOverflow, nil, "if x {\n    y()  \n\n}\n")
+		if want := "> if x {\n>     y()  \n>\n> }\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("begins on next line", func(t *testing.T) {
+		got := emit(t, 20, "", golinewrap.PreformattedOverflow, []string{"code:"}, "  x := 1")
+		if want := "code:\n  x := 1\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		got := emit(t, 8, "", golinewrap.PreformattedOverflow, nil, "abcdefghijkl")
+		if want := "abcdefghijkl\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("break", func(t *testing.T) {
+		got := emit(t, 8, "| ", golinewrap.PreformattedBreak, nil, "abcdefghijkl")
+		if want := "| abcd\\\n| efgh\\\n| ijkl\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("break exact fit", func(t *testing.T) {
+		got := emit(t, 8, "", golinewrap.PreformattedBreak, nil, "abcdefg")
+		if want := "abcdefg\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+}
+
+func TestWritePreformattedWithSuffix(t *testing.T) {
+	bb := new(bytes.Buffer)
+
+	lw, err := golinewrap.NewWithOptions(bb,
+		golinewrap.WithWidth(12),
+		golinewrap.WithPrefix("| "),
+		golinewrap.WithSuffix(" |"),
+		golinewrap.WithPreformattedPolicy(golinewrap.PreformattedBreak),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = lw.WritePreformatted("abcdefghijklmno\n  x"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := string(bb.Bytes()), "| abcdef- |\n| ghijkl- |\n| mno     |\n|   x     |\n"; got != want {
+		t.Errorf("GOT: %q; WANT: %q", got, want)
+	}
+}
+
+func TestSetPreformattedPolicy(t *testing.T) {
+	lw, err := golinewrap.New(new(bytes.Buffer), 8, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = lw.SetPreformattedPolicy(golinewrap.PreformattedPolicy(42)); err == nil {
+		t.Errorf("GOT: %v; WANT: %v", err, "error")
+	}
+}
@@ -0,0 +1,100 @@
+package golinewrap
+
+import "io"
+
+// ReflowReader is an io.Reader that reads text in which paragraphs are
+// separated by blank lines, and produces the same text with each paragraph on
+// a single line. Each newline inside a paragraph is replaced by a space, and
+// each run of blank lines between paragraphs is replaced by a single newline.
+// Lines with nothing but spaces and tabs are blank, carriage returns before a
+// newline are removed, and blank lines before the first paragraph are dropped.
+// When any text is read, the final paragraph is ended by a newline.
+//
+// Because each paragraph is on its own line, the output may be read a line at
+// a time and given to WriteParagraph without holding more than one paragraph
+// in memory.
+type ReflowReader struct {
+	r   io.Reader
+	buf []byte // bytes read from r
+	out []byte // reflowed bytes not yet returned
+	rf  reflower
+	err error // error returned by r
+}
+
+// NewReflowReader returns a ReflowReader that reads from r.
+func NewReflowReader(r io.Reader) *ReflowReader {
+	return &ReflowReader{r: r, buf: make([]byte, 4096)}
+}
+
+// Read reads reflowed text into p.
+func (rr *ReflowReader) Read(p []byte) (int, error) {
+	for len(rr.out) == 0 && rr.err == nil {
+		n, err := rr.r.Read(rr.buf)
+		rr.out = rr.rf.reflow(rr.out, rr.buf[:n])
+		if err != nil {
+			rr.err = err
+			if err == io.EOF {
+				rr.out = rr.rf.end(rr.out)
+			}
+		}
+	}
+
+	if len(rr.out) == 0 {
+		return 0, rr.err
+	}
+
+	n := copy(p, rr.out)
+	if n == len(rr.out) {
+		rr.out = rr.out[:0]
+	} else {
+		rr.out = rr.out[n:]
+	}
+	return n, nil
+}
+
+// reflower joins the lines of each paragraph of the text given to it, putting
+// each paragraph on a single line, as described for ReflowReader.
+type reflower struct {
+	held     []byte // spaces, tabs, and carriage returns not yet known to be followed by text
+	newlines int    // number of newlines since text
+	started  bool   // whether any text has been reflowed
+}
+
+// reflow appends the reflowed form of buf to out and returns the result.
+func (rf *reflower) reflow(out, buf []byte) []byte {
+	for _, b := range buf {
+		switch b {
+		case '\n':
+			rf.held = rf.held[:0] // drop white space at end of line
+			rf.newlines++
+		case ' ', '\t', '\r':
+			rf.held = append(rf.held, b)
+		default:
+			switch {
+			case rf.newlines == 0:
+				out = append(out, rf.held...)
+			case !rf.started:
+				// drop blank lines before first paragraph
+			case rf.newlines == 1:
+				out = append(out, ' ') // join lines of paragraph
+			default:
+				out = append(out, '\n') // end previous paragraph
+			}
+			out = append(out, b)
+			rf.held = rf.held[:0]
+			rf.newlines = 0
+			rf.started = true
+		}
+	}
+	return out
+}
+
+// end appends the newline that ends the final paragraph to out, when there is
+// one, and returns the result. The reflower is reset to reflow new text.
+func (rf *reflower) end(out []byte) []byte {
+	if rf.started {
+		out = append(out, '\n')
+	}
+	*rf = reflower{held: rf.held[:0]}
+	return out
+}
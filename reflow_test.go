@@ -0,0 +1,65 @@
+package golinewrap_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/karrick/golinewrap"
+)
+
+func TestReflowReader(t *testing.T) {
+	emit := func(t *testing.T, s string) string {
+		t.Helper()
+		// Read one byte at a time to ensure state is kept across reads.
+		buf, err := io.ReadAll(iotest.OneByteReader(golinewrap.NewReflowReader(iotest.OneByteReader(strings.NewReader(s)))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(buf)
+	}
+
+	tests := []struct {
+		name, input, want string
+	}{
+		{"empty", "", ""},
+		{"only blank lines", "\n \n\t\n", ""},
+		{"single line", "one two", "one two\n"},
+		{"joins lines", "one\ntwo\nthree\n", "one two three\n"},
+		{"paragraphs", "one\ntwo\n\nthree\n", "one two\nthree\n"},
+		{"runs of blank lines", "\n\none\n\n\n \t\n\ntwo\n\n\n", "one\ntwo\n"},
+		{"crlf", "one\r\ntwo\r\n\r\nthree\r\n", "one two\nthree\n"},
+		{"keeps white space inside line", "one  two\tthree\n", "one  two\tthree\n"},
+		{"drops white space at end of line", "one  \ntwo \n", "one two\n"},
+		{"multibyte", "café\nnaïve\n", "café naïve\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := emit(t, tt.input); got != tt.want {
+				t.Errorf("GOT: %q; WANT: %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("writer", func(t *testing.T) {
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, 16, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, p := range strings.Split(strings.TrimSuffix(emit(t, "one two\nthree four\nfive\n\nsix\n"), "\n"), "\n") {
+			if _, err = lw.WriteParagraph(p); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if got, want := string(bb.Bytes()), "one two three\nfour five\n\nsix\n\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+}
@@ -0,0 +1,132 @@
+package golinewrap
+
+import (
+	"bytes"
+	"io"
+	"strings"
+)
+
+// SetStreaming changes whether Write treats its input as a stream. By default
+// each buffer given to Write is taken to be complete, so a word split between
+// two calls is written as two words, and the end of each buffer ends a
+// paragraph. When streaming, Write holds a partial word and a partial line
+// until later calls complete them, so input may be written in chunks of any
+// size, such as by io.Copy. Each line is still written as its newlines
+// require, but only once its newline has been written. Close ends the stream,
+// writing any text still held.
+//
+// With BreakOptimal, the whole of each line is held until it is complete, so
+// its line breaks may be chosen together; otherwise complete words are written
+// as soon as they are known.
+func (ww *Writer) SetStreaming(streaming bool) {
+	ww.streaming = streaming
+}
+
+// writeStream holds buf with the text not yet written, then writes each
+// complete line as a paragraph, followed by the complete words of any partial
+// line.
+func (ww *Writer) writeStream(buf []byte) (int, error) {
+	if ww.newlines == NewlineSoft {
+		ww.in = ww.rf.reflow(ww.in, buf)
+	} else {
+		ww.in = append(ww.in, buf...)
+	}
+
+	if i := bytes.LastIndexByte(ww.in, '\n'); i >= 0 {
+		lines := string(ww.in[:i])
+		ww.in = append(ww.in[:0], ww.in[i+1:]...)
+
+		for _, p := range strings.Split(lines, "\n") {
+			if err := ww.writeLine(p); err != nil {
+				return len(buf), err // buf is held, so must not be written again
+			}
+		}
+		ww.partial = false
+	}
+
+	if ww.breaking == BreakOptimal {
+		return len(buf), nil
+	}
+
+	// Text after the final breaking space may be the start of a longer word,
+	// but the words before it are complete.
+	i := bytes.LastIndexFunc(ww.in, isBreakingSpace)
+	if i < 0 {
+		return len(buf), nil
+	}
+
+	words := string(ww.in[:i])
+	ww.in = append(ww.in[:0], bytes.TrimLeftFunc(ww.in[i:], isBreakingSpace)...)
+
+	if strings.TrimFunc(words, isBreakingSpace) != "" {
+		if _, err := ww.WriteWord(words); err != nil {
+			return len(buf), err // buf is held, so must not be written again
+		}
+		ww.partial = true
+	}
+
+	return len(buf), nil
+}
+
+// Flush writes to the underlying io.Writer the text that has been wrapped but
+// not yet written, up to the end of the current line, other than a trailing
+// space that may yet be removed should the line end. When streaming, a
+// partial word is held, because later input may extend it. Unless text is left
+// aligned, the current line is held until it is complete. The prefix of a
+// line without text is not written.
+func (ww *Writer) Flush() error {
+	if ww.lineEmpty() || ww.alignment != AlignLeft {
+		return nil
+	}
+
+	// Hold a trailing space, which is removed should the line end after it.
+	b := ww.lb.Bytes()
+	n := len(b)
+	if n > 0 && b[n-1] == ' ' {
+		n--
+	}
+	if n == 0 {
+		return nil
+	}
+
+	nw, err := ww.Writer.Write(b[:n])
+	ww.lb.Next(nw)
+	ww.written += int64(nw)
+	ww.lineStart = 0
+	if err == nil && nw < n {
+		err = io.ErrShortWrite
+	}
+	return err
+}
+
+// Close ends the stream of text written by Write when streaming, writing any
+// text still held as the final line, and ends the current line when it has
+// text, such as that written by WriteWord, so the output ends with a newline.
+// The trailing space left by WriteWord is removed, and the prefix of the next
+// line, having no text, is never written. The Writer may be used again after
+// it is closed, but no longer watches for the terminal being resized. It does
+// not close the underlying io.Writer.
+func (ww *Writer) Close() error {
+	ww.stopResizeWatch()
+
+	if ww.newlines == NewlineSoft {
+		ww.in = ww.rf.end(ww.in)
+		if _, err := ww.writeStream(nil); err != nil {
+			return err
+		}
+	}
+
+	if len(ww.in) == 0 && !ww.partial {
+		if ww.lineEmpty() {
+			return nil
+		}
+		_, err := ww.newline()
+		return err
+	}
+
+	p := string(ww.in)
+	ww.in = ww.in[:0]
+	ww.partial = false
+
+	return ww.writeLine(p)
+}
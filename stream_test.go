@@ -0,0 +1,193 @@
+package golinewrap_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/karrick/golinewrap"
+)
+
+func TestStreaming(t *testing.T) {
+	const input = "One two three four five six seven eight nine ten.\nEleven twelve thirteen.\n\nFourteen fifteen"
+
+	emit := func(t *testing.T, breaking golinewrap.Breaking, chunk int) string {
+		t.Helper()
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, 13, "> ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.SetBreaking(breaking); err != nil {
+			t.Fatal(err)
+		}
+		lw.SetStreaming(true)
+
+		for s := input; s != ""; {
+			n := chunk
+			if n > len(s) {
+				n = len(s)
+			}
+			nw, err := lw.Write([]byte(s[:n]))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if nw != n {
+				t.Fatalf("GOT: %v; WANT: %v", nw, n)
+			}
+			s = s[n:]
+		}
+
+		if err = lw.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		return string(bb.Bytes())
+	}
+
+	const want = "> One two\n> three four\n> five six\n> seven\n> eight nine\n> ten.\n>\n> Eleven\n> twelve\n> thirteen.\n>\n>\n>\n> Fourteen\n> fifteen\n>\n"
+
+	for _, chunk := range []int{1, 2, 3, 5, 7, 11, len(input)} {
+		if got := emit(t, golinewrap.BreakGreedy, chunk); got != want {
+			t.Errorf("CHUNK: %d; GOT: %q; WANT: %q", chunk, got, want)
+		}
+	}
+
+	t.Run("optimal", func(t *testing.T) {
+		want := emit(t, golinewrap.BreakOptimal, len(input))
+		for _, chunk := range []int{1, 2, 3, 5, 7, 11} {
+			if got := emit(t, golinewrap.BreakOptimal, chunk); got != want {
+				t.Errorf("CHUNK: %d; GOT: %q; WANT: %q", chunk, got, want)
+			}
+		}
+	})
+
+	t.Run("io.Copy", func(t *testing.T) {
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, 13, "> ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		lw.SetStreaming(true)
+
+		nw, err := io.Copy(lw, iotest.HalfReader(strings.NewReader(input)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := nw, int64(len(input)); got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		if err = lw.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := string(bb.Bytes()); got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("error consumes buffer", func(t *testing.T) {
+		lw, err := golinewrap.NewWithOptions(new(bytes.Buffer),
+			golinewrap.WithWidth(8),
+			golinewrap.WithLongWordPolicy(golinewrap.LongWordError),
+			golinewrap.WithStreaming(true),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, b := range []string{"abcdefghijkl\n", "abcdefghijkl one"} {
+			nw, err := lw.Write([]byte(b))
+			if !errors.Is(err, golinewrap.ErrWordTooLong) {
+				t.Errorf("GOT: %v; WANT: %v", err, golinewrap.ErrWordTooLong)
+			}
+			if got, want := nw, len(b); got != want {
+				t.Errorf("GOT: %v; WANT: %v", got, want)
+			}
+		}
+	})
+
+	t.Run("flush holds partial word", func(t *testing.T) {
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, 13, "> ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		lw.SetStreaming(true)
+
+		if _, err = lw.Write([]byte("one tw")); err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := string(bb.Bytes()), "> one"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+
+		if _, err = lw.Write([]byte("o\n")); err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := string(bb.Bytes()), "> one two\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+}
+
+var _ io.WriteCloser = (*golinewrap.Writer)(nil)
+
+func TestClose(t *testing.T) {
+	emit := func(t *testing.T, fn func(*golinewrap.Writer) error) string {
+		t.Helper()
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, 16, "> ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = fn(lw); err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		return string(bb.Bytes())
+	}
+
+	t.Run("nothing written", func(t *testing.T) {
+		got := emit(t, func(lw *golinewrap.Writer) error { return nil })
+		if want := ""; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("ends line of words", func(t *testing.T) {
+		got := emit(t, func(lw *golinewrap.Writer) error {
+			_, err := lw.WriteWord("one")
+			return err
+		})
+		if want := "> one\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("no dangling prefix", func(t *testing.T) {
+		got := emit(t, func(lw *golinewrap.Writer) error {
+			_, err := lw.WriteParagraph("one")
+			return err
+		})
+		if want := "> one\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+}
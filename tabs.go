@@ -0,0 +1,113 @@
+package golinewrap
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TabOrigin determines the column from which tab stops are measured.
+type TabOrigin int
+
+const (
+	// TabsFromLineStart measures tab stops from the start of each line,
+	// including its prefix, as a terminal does. This is the default.
+	TabsFromLineStart TabOrigin = iota
+
+	// TabsFromPrefix measures tab stops from the end of the prefix of each
+	// line, so text indented with tabs lines up the same way whatever the
+	// width of the prefix.
+	TabsFromPrefix
+)
+
+// SetTabWidth changes the number of columns between tab stops. A tab given to
+// WriteRune or WritePreformatted is expanded to the spaces that reach the next
+// tab stop. The default tab width is 8. It returns an error unless n is
+// greater than zero.
+func (ww *Writer) SetTabWidth(n int) error {
+	if n <= 0 {
+		return fmt.Errorf("cannot set tab width unless it is greater than zero: %d.", n)
+	}
+	ww.tabWidth = n
+	return nil
+}
+
+// SetTabOrigin changes the column from which tab stops are measured.
+func (ww *Writer) SetTabOrigin(o TabOrigin) error {
+	if o != TabsFromLineStart && o != TabsFromPrefix {
+		return fmt.Errorf("cannot set unknown tab origin: %d", o)
+	}
+	ww.tabOrigin = o
+	return nil
+}
+
+// tabColumn returns the column of the current line at which the next rune
+// would be written, measured from the tab origin.
+func (ww *Writer) tabColumn() int {
+	column := ww.max - ww.suffixColumns - ww.remaining
+	if ww.tabOrigin == TabsFromPrefix {
+		column -= ww.lineColumns
+	}
+	return column
+}
+
+// tabSpaces returns the number of spaces that expand a tab written at column,
+// measured from the tab origin.
+func (ww *Writer) tabSpaces(column int) int {
+	return ww.tabWidth - column%ww.tabWidth
+}
+
+// expandTabs returns s with each tab expanded to spaces, as though s were
+// written at the current column of the current line.
+func (ww *Writer) expandTabs(s string) string {
+	if strings.IndexByte(s, '\t') < 0 {
+		return s
+	}
+
+	var b strings.Builder
+	column := ww.tabColumn()
+
+	for {
+		i := strings.IndexByte(s, '\t')
+		if i < 0 {
+			b.WriteString(s)
+			return b.String()
+		}
+		b.WriteString(s[:i])
+		column += ww.columns(s[:i])
+
+		n := ww.tabSpaces(column)
+		b.WriteString(strings.Repeat(" ", n))
+		column += n
+
+		s = s[i+1:]
+	}
+}
+
+// writeTab writes the spaces that expand a tab at the current column, moving
+// to the next line first when they do not fit on the current line.
+func (ww *Writer) writeTab() (int, error) {
+	var tw int // total written
+
+	n := ww.tabSpaces(ww.tabColumn())
+	if ww.remaining < n+1 && !ww.lineEmpty() {
+		nw, err := ww.wrap()
+		tw += nw
+		if err != nil {
+			return tw, err
+		}
+		n = ww.tabSpaces(ww.tabColumn())
+	}
+	if n > ww.remaining-1 {
+		n = ww.remaining - 1 // tab stop beyond end of line
+	}
+
+	for i := 0; i < n; i++ {
+		nw, err := ww.WriteRune(' ')
+		tw += nw
+		if err != nil {
+			return tw, err
+		}
+	}
+
+	return tw, nil
+}
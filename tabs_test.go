@@ -0,0 +1,103 @@
+package golinewrap_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/karrick/golinewrap"
+)
+
+func TestTabs(t *testing.T) {
+	emit := func(t *testing.T, width int, prefix string, tabWidth int, origin golinewrap.TabOrigin, fn func(*golinewrap.Writer) error) string {
+		t.Helper()
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.NewWithOptions(bb,
+			golinewrap.WithWidth(width),
+			golinewrap.WithPrefix(prefix),
+			golinewrap.WithTabWidth(tabWidth),
+			golinewrap.WithTabOrigin(origin),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = fn(lw); err != nil {
+			t.Fatal(err)
+		}
+
+		return string(bb.Bytes())
+	}
+
+	runes := func(s string) func(*golinewrap.Writer) error {
+		return func(lw *golinewrap.Writer) error {
+			for _, r := range s {
+				if _, err := lw.WriteRune(r); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	preformatted := func(s string) func(*golinewrap.Writer) error {
+		return func(lw *golinewrap.Writer) error {
+			_, err := lw.WritePreformatted(s)
+			return err
+		}
+	}
+
+	t.Run("rune from line start", func(t *testing.T) {
+		got := emit(t, 20, "> ", 4, golinewrap.TabsFromLineStart, runes("a\tb\tc\n"))
+		if want := "> a b   c\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("rune from prefix", func(t *testing.T) {
+		got := emit(t, 20, "> ", 4, golinewrap.TabsFromPrefix, runes("a\tb\tc\n"))
+		if want := "> a   b   c\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("rune wraps", func(t *testing.T) {
+		got := emit(t, 8, "", 4, golinewrap.TabsFromLineStart, runes("abcdef\tg\n"))
+		if want := "abcdef\n    g\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("rune wide", func(t *testing.T) {
+		got := emit(t, 20, "", 4, golinewrap.TabsFromLineStart, runes("世\tb\n"))
+		if want := "世  b\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("preformatted", func(t *testing.T) {
+		got := emit(t, 20, "| ", 8, golinewrap.TabsFromPrefix, preformatted("if x {\n\ty()\n\t\tz\n}"))
+		if want := "| if x {\n|         y()\n|                 z\n| }\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("preformatted from line start", func(t *testing.T) {
+		got := emit(t, 20, "| ", 8, golinewrap.TabsFromLineStart, preformatted("\ty()"))
+		if want := "|       y()\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		lw, err := golinewrap.New(new(bytes.Buffer), 20, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.SetTabWidth(0); err == nil {
+			t.Errorf("GOT: %v; WANT: %v", err, "error")
+		}
+		if err = lw.SetTabOrigin(golinewrap.TabOrigin(42)); err == nil {
+			t.Errorf("GOT: %v; WANT: %v", err, "error")
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package golinewrap
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"sync/atomic"
+)
+
+// DefaultTerminalWidth is the width TerminalWidth returns when it cannot
+// otherwise determine the width of a terminal.
+const DefaultTerminalWidth = 80
+
+// fder is implemented by io.Writers, such as *os.File, that have a file
+// descriptor.
+type fder interface {
+	Fd() uintptr
+}
+
+// TerminalWidth returns the number of columns of the terminal w writes to.
+// When w is not a terminal, or its width cannot be determined, it returns the
+// value of the COLUMNS environment variable, or DefaultTerminalWidth when that
+// is not a positive number.
+func TerminalWidth(w io.Writer) int {
+	if f, ok := w.(fder); ok {
+		if n := terminalColumns(f.Fd()); n > 0 {
+			return n
+		}
+	}
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+	return DefaultTerminalWidth
+}
+
+// NewForTerminal returns a new Writer that writes to w, using the width
+// TerminalWidth returns for w, unless opts include WithWidth. Because the
+// width includes the column used by the newline character, no line fills the
+// final column of the terminal.
+func NewForTerminal(w io.Writer, opts ...Option) (*Writer, error) {
+	return NewWithOptions(w, append([]Option{WithWidth(TerminalWidth(w))}, opts...)...)
+}
+
+// WithResizeWatch sets whether the Writer watches for the terminal it writes
+// to being resized, and changes its width to match, starting with the line
+// after the resize. Resizes that leave no room for the prefix and suffix are
+// ignored. Watching is only possible on Linux, when the underlying io.Writer
+// is a terminal. Close stops watching.
+func WithResizeWatch(watch bool) Option {
+	return func(c *config) { c.watchResize = watch }
+}
+
+// checkWidth returns a WidthError unless width is greater than the number of
+// columns used by each prefix together with indentation of indentColumns and
+// the suffix.
+func (ww *Writer) checkWidth(width, indentColumns int) error {
+	if width <= 0 {
+		return &WidthError{Op: "set width", Width: width}
+	}
+	for _, prefixColumns := range []int{ww.prefixColumns, ww.firstColumns} {
+		if width <= prefixColumns+indentColumns+ww.suffixColumns {
+			return &WidthError{Op: "set width", Width: width, Used: "prefix and suffix", Columns: prefixColumns + indentColumns + ww.suffixColumns}
+		}
+	}
+	return nil
+}
+
+// applyResize changes the width to that of the terminal when it has been
+// resized since the previous line. A width set by SetWidth for the next line
+// takes precedence.
+func (ww *Writer) applyResize() {
+	if n := int(atomic.SwapInt32(&ww.resize, 0)); n > 0 && ww.checkWidth(n, ww.indentColumns) == nil {
+		ww.max = n
+	}
+}
+
+// stopResizeWatch stops watching for the terminal being resized.
+func (ww *Writer) stopResizeWatch() {
+	if ww.stopResize != nil {
+		ww.stopResize()
+		ww.stopResize = nil
+	}
+}
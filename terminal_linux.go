@@ -0,0 +1,55 @@
+package golinewrap
+
+import (
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"unsafe"
+)
+
+// terminalColumns returns the number of columns of the terminal with file
+// descriptor fd, or zero when fd is not a terminal.
+func terminalColumns(fd uintptr) int {
+	var ws struct {
+		Row, Col, Xpixel, Ypixel uint16
+	}
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, syscall.TIOCGWINSZ, uintptr(unsafe.Pointer(&ws)))
+	if errno != 0 {
+		return 0
+	}
+	return int(ws.Col)
+}
+
+// watchResize starts watching for SIGWINCH, recording the new width of the
+// terminal the Writer writes to each time it is resized. It does nothing when
+// the underlying io.Writer is not a terminal.
+func (ww *Writer) watchResize() {
+	f, ok := ww.Writer.(fder)
+	if !ok || terminalColumns(f.Fd()) == 0 {
+		return
+	}
+	fd := f.Fd()
+
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, syscall.SIGWINCH)
+
+	go func() {
+		for {
+			select {
+			case <-signals:
+				if n := terminalColumns(fd); n > 0 {
+					atomic.StoreInt32(&ww.resize, int32(n))
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	ww.stopResize = func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
@@ -0,0 +1,137 @@
+package golinewrap_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/karrick/golinewrap"
+)
+
+// ioctl performs the ioctl request on fd with argument arg.
+func ioctl(fd, request uintptr, arg unsafe.Pointer) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, request, uintptr(arg)); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// openPty returns the master and slave of a new pseudo terminal, skipping the
+// test when one cannot be opened.
+func openPty(t *testing.T) (*os.File, *os.File) {
+	t.Helper()
+
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR, 0)
+	if err != nil {
+		t.Skip(err)
+	}
+	t.Cleanup(func() { _ = master.Close() })
+
+	var unlock int32
+	if err = ioctl(master.Fd(), syscall.TIOCSPTLCK, unsafe.Pointer(&unlock)); err != nil {
+		t.Skip(err)
+	}
+	var n uint32
+	if err = ioctl(master.Fd(), syscall.TIOCGPTN, unsafe.Pointer(&n)); err != nil {
+		t.Skip(err)
+	}
+
+	slave, err := os.OpenFile(fmt.Sprintf("/dev/pts/%d", n), os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		t.Skip(err)
+	}
+	t.Cleanup(func() { _ = slave.Close() })
+
+	return master, slave
+}
+
+// setPtyColumns sets the number of columns of the pseudo terminal with master
+// f.
+func setPtyColumns(t *testing.T, f *os.File, columns int) {
+	t.Helper()
+	ws := struct {
+		Row, Col, Xpixel, Ypixel uint16
+	}{Row: 24, Col: uint16(columns)}
+	if err := ioctl(f.Fd(), syscall.TIOCSWINSZ, unsafe.Pointer(&ws)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// syncBuffer is a bytes.Buffer that may be written and read concurrently.
+type syncBuffer struct {
+	mu sync.Mutex
+	bb bytes.Buffer
+}
+
+func (sb *syncBuffer) Write(p []byte) (int, error) {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	return sb.bb.Write(p)
+}
+
+func (sb *syncBuffer) String() string {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	return strings.ReplaceAll(sb.bb.String(), "\r\n", "\n")
+}
+
+func TestResizeWatch(t *testing.T) {
+	master, slave := openPty(t)
+	setPtyColumns(t, master, 20)
+
+	var out syncBuffer
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, err := master.Read(buf)
+			_, _ = out.Write(buf[:n])
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	lw, err := golinewrap.NewForTerminal(slave, golinewrap.WithPrefix("> "), golinewrap.WithResizeWatch(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lw.Close()
+
+	const p = "aaa bbb ccc ddd"
+
+	if _, err = lw.WriteParagraph(p); err != nil {
+		t.Fatal(err)
+	}
+
+	setPtyColumns(t, master, 12)
+	if err = syscall.Kill(os.Getpid(), syscall.SIGWINCH); err != nil {
+		t.Fatal(err)
+	}
+
+	// The width changes with the line after the signal is received, so keep
+	// writing until it does.
+	const want = "> aaa bbb\n> ccc ddd\n"
+	for deadline := time.Now().Add(5 * time.Second); ; {
+		if _, err = lw.WriteParagraph(p); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		got := out.String()
+		if strings.Contains(got, want) {
+			if !strings.HasPrefix(got, "> "+p+"\n") {
+				t.Errorf("GOT: %q; WANT: %q", got, "> "+p+"\n")
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("GOT: %q; WANT: %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !linux
+// +build !linux
+
+package golinewrap
+
+// terminalColumns returns zero, because terminal widths are only detected on
+// Linux.
+func terminalColumns(fd uintptr) int { return 0 }
+
+// watchResize does nothing, because resizes are only watched on Linux.
+func (ww *Writer) watchResize() {}
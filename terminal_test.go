@@ -0,0 +1,75 @@
+package golinewrap_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/karrick/golinewrap"
+)
+
+func TestTerminalWidth(t *testing.T) {
+	t.Run("columns", func(t *testing.T) {
+		t.Setenv("COLUMNS", "42")
+		if got, want := golinewrap.TerminalWidth(new(bytes.Buffer)), 42; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	})
+
+	t.Run("default", func(t *testing.T) {
+		t.Setenv("COLUMNS", "bogus")
+		if got, want := golinewrap.TerminalWidth(new(bytes.Buffer)), golinewrap.DefaultTerminalWidth; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	})
+
+	t.Run("not a terminal", func(t *testing.T) {
+		t.Setenv("COLUMNS", "")
+		f, err := os.CreateTemp("", "golinewrap")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(f.Name())
+		defer f.Close()
+
+		if got, want := golinewrap.TerminalWidth(f), golinewrap.DefaultTerminalWidth; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	})
+}
+
+func TestNewForTerminal(t *testing.T) {
+	emit := func(t *testing.T, opts ...golinewrap.Option) string {
+		t.Helper()
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.NewForTerminal(bb, opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = lw.WriteParagraph("one two three four"); err != nil {
+			t.Fatal(err)
+		}
+		if err = lw.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		return string(bb.Bytes())
+	}
+
+	t.Run("detected width", func(t *testing.T) {
+		t.Setenv("COLUMNS", "12")
+		got := emit(t, golinewrap.WithPrefix("> "), golinewrap.WithResizeWatch(true))
+		if want := "> one two\n> three\n> four\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("width option overrides", func(t *testing.T) {
+		t.Setenv("COLUMNS", "12")
+		got := emit(t, golinewrap.WithWidth(20))
+		if want := "one two three four\n\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package golinewrap_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/karrick/golinewrap"
+)
+
+func TestMeasureCells(t *testing.T) {
+	emit := func(t *testing.T, width int, prefix string, p string) string {
+		bb := new(bytes.Buffer)
+
+		lw, err := golinewrap.New(bb, width, prefix)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = lw.WriteParagraph(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return string(bb.Bytes())
+	}
+
+	t.Run("wide characters use two columns", func(t *testing.T) {
+		got := emit(t, 10, ">", "日本語 テキスト")
+		if want := ">日本語\n>テキスト\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("combining marks use zero columns", func(t *testing.T) {
+		got := emit(t, 12, ">", "cafe\u0301 cre\u0300me")
+		if want := ">cafe\u0301 cre\u0300me\n>\n"; got != want {
+			t.Errorf("GOT: %q; WANT: %q", got, want)
+		}
+	})
+
+	t.Run("wide prefix", func(t *testing.T) {
+		if _, err := golinewrap.New(new(bytes.Buffer), 4, "注意"); err == nil {
+			t.Errorf("GOT: %v; WANT: error", err)
+		}
+	})
+}
+
+func TestMeasureRunes(t *testing.T) {
+	bb := new(bytes.Buffer)
+
+	lw, err := golinewrap.New(bb, 10, ">")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = lw.SetMeasure(golinewrap.MeasureRunes); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = lw.WriteParagraph("日本語 テキスト"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := string(bb.Bytes()), ">日本語 テキスト\n>\n"; got != want {
+		t.Errorf("GOT: %q; WANT: %q", got, want)
+	}
+}
+
+func TestWriteRuneWide(t *testing.T) {
+	bb := new(bytes.Buffer)
+
+	lw, err := golinewrap.New(bb, 6, ">")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, r := range "日本語" {
+		if _, err = lw.WriteRune(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got, want := string(bb.Bytes()), ">日本\n>語"; got != want {
+		t.Errorf("GOT: %q; WANT: %q", got, want)
+	}
+}